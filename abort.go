@@ -0,0 +1,142 @@
+package pusher
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type (
+	// Abort is a rule that stops the run early when the Target is clearly broken,
+	// so the Worker doesn't hammer it until the context expires, see WithAborts.
+	// The zero Abort never trips.
+	Abort struct {
+		// sensor creates the fresh state of the rule for a single run: the returned
+		// function is fed by every finished task and returns the reason to abort
+		// the run or an empty string.
+		sensor func() func(gossip *Gossip) string
+	}
+
+	// guard checks the finished tasks of a single run against the abort rules.
+	guard struct {
+		cancel  context.CancelCauseFunc
+		sensors []func(gossip *Gossip) string
+		mutex   sync.Mutex
+	}
+
+	// outcome is a finished task remembered by the ErrorRatio rule.
+	outcome struct {
+		end    time.Time
+		failed bool
+	}
+)
+
+// ErrorRatio creates the Abort rule that trips when the share of failed tasks
+// among the ones finished within the sliding window exceeds the limit (from 0 to 1).
+// The rule waits for at least the given amount of tasks in the window,
+// so a single early failure doesn't abort the run.
+func ErrorRatio(limit float64, window time.Duration, least int) Abort {
+	return Abort{sensor: func() func(gossip *Gossip) string {
+		var (
+			outcomes = make([]outcome, 0)
+			failed   int
+		)
+
+		return func(gossip *Gossip) string {
+			outcomes = append(outcomes, outcome{end: gossip.End, failed: gossip.Error != nil})
+			if gossip.Error != nil {
+				failed++
+			}
+
+			for len(outcomes) > 0 && gossip.End.Sub(outcomes[0].end) > window {
+				if outcomes[0].failed {
+					failed--
+				}
+
+				outcomes = outcomes[1:]
+			}
+
+			if len(outcomes) < least {
+				return ""
+			}
+
+			ratio := float64(failed) / float64(len(outcomes))
+			if ratio <= limit {
+				return ""
+			}
+
+			return fmt.Sprintf("error ratio %.2f%% > %.2f%% within %s", ratio*percent, limit*percent, window)
+		}
+	}}
+}
+
+// Failures creates the Abort rule that trips after the given amount of failed
+// tasks in a row (in order of their completion).
+func Failures(streak int) Abort {
+	return Abort{sensor: func() func(gossip *Gossip) string {
+		var row int
+
+		return func(gossip *Gossip) string {
+			if gossip.Error == nil {
+				row = 0
+
+				return ""
+			}
+
+			row++
+			if row < streak {
+				return ""
+			}
+
+			return fmt.Sprintf("%d failures in a row", row)
+		}
+	}}
+}
+
+// Ceiling creates the Abort rule that trips as soon as a single task
+// takes longer than the limit (see Gossip.Latency).
+func Ceiling(limit time.Duration) Abort {
+	return Abort{sensor: func() func(gossip *Gossip) string {
+		return func(gossip *Gossip) string {
+			latency := gossip.Latency()
+			if latency <= limit {
+				return ""
+			}
+
+			return fmt.Sprintf("latency %s > %s", latency, limit)
+		}
+	}}
+}
+
+// newGuard creates the guard for the abort rules that stops the run with the cancel function.
+func newGuard(aborts []Abort, cancel context.CancelCauseFunc) *guard {
+	sensors := make([]func(gossip *Gossip) string, 0, len(aborts))
+	for _, abort := range aborts {
+		if abort.sensor == nil {
+			continue
+		}
+
+		sensors = append(sensors, abort.sensor())
+	}
+
+	return &guard{cancel: cancel, sensors: sensors, mutex: sync.Mutex{}}
+}
+
+// check feeds the finished task to the rules and aborts the run if any of them trips.
+func (g *guard) check(gossip *Gossip) {
+	if len(g.sensors) == 0 {
+		return
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for _, sensor := range g.sensors {
+		if reason := sensor(gossip); reason != "" {
+			g.cancel(ErrAborted.Reason(reason))
+
+			return
+		}
+	}
+}
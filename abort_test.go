@@ -0,0 +1,145 @@
+package pusher_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therenotomorrow/ex"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+func TestWorkerWorkAborts(t *testing.T) {
+	t.Parallel()
+
+	sleepy := func(_ context.Context) (pusher.Result, error) {
+		time.Sleep(100 * time.Millisecond)
+
+		return result("done"), nil
+	}
+
+	type want struct {
+		err    error
+		reason string
+	}
+
+	tests := []struct {
+		want   want
+		target pusher.Target
+		name   string
+		aborts []pusher.Abort
+	}{
+		{
+			name:   "healthy",
+			target: noop(),
+			aborts: []pusher.Abort{
+				pusher.ErrorRatio(0.5, time.Second, 10),
+				pusher.Failures(1),
+				pusher.Ceiling(time.Second),
+			},
+			want: want{err: nil, reason: ""},
+		},
+		{
+			name:   "error ratio",
+			target: broken(),
+			aborts: []pusher.Abort{pusher.ErrorRatio(0.5, time.Second, 10)},
+			want:   want{err: pusher.ErrAborted, reason: "error ratio 100.00% > 50.00% within 1s"},
+		},
+		{
+			name:   "failures",
+			target: broken(),
+			aborts: []pusher.Abort{pusher.Failures(5)},
+			want:   want{err: pusher.ErrAborted, reason: "5 failures in a row"},
+		},
+		{
+			name:   "ceiling",
+			target: sleepy,
+			aborts: []pusher.Abort{pusher.Ceiling(50 * time.Millisecond)},
+			want:   want{err: pusher.ErrAborted, reason: "latency"},
+		},
+		{
+			name:   "zero value",
+			target: broken(),
+			aborts: []pusher.Abort{{}},
+			want:   want{err: nil, reason: ""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			duration := time.Second
+
+			got, err := pusher.Work(pusher.Steady(100), duration, test.target, pusher.WithAborts(test.aborts...))
+
+			require.ErrorIs(t, err, test.want.err)
+
+			if test.want.err == nil {
+				return
+			}
+
+			assert.Contains(t, err.Error(), test.want.reason)
+			assert.Less(t, got.Duration, duration/2)
+		})
+	}
+}
+
+func TestWorkerWorkAbortsWindow(t *testing.T) {
+	t.Parallel()
+
+	var num int
+
+	// the failures in the beginning would trip the rule, but they leave
+	// the window before it holds enough tasks to be checked
+	target := func(_ context.Context) (pusher.Result, error) {
+		num++
+
+		if num <= 4 {
+			return nil, ex.ErrUnexpected
+		}
+
+		return result("done"), nil
+	}
+
+	got, err := pusher.Work(
+		pusher.Steady(50),
+		time.Second,
+		target,
+		pusher.WithOvertime(1),
+		pusher.WithAborts(pusher.ErrorRatio(0.5, 50*time.Millisecond, 5)),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), got.Failed)
+}
+
+func TestWorkerCrewAborts(t *testing.T) {
+	t.Parallel()
+
+	_, err := pusher.Crew(3, time.Second, broken(), pusher.WithAborts(pusher.Failures(3)))
+
+	require.ErrorIs(t, err, pusher.ErrAborted)
+}
+
+func TestFarmAborts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		duration = 5 * time.Second
+		obs      = newObserver()
+		workers  = []*pusher.Worker{
+			pusher.Hire("#1", noop(), pusher.WithGossips(obs)),
+			pusher.Hire("#2", broken(), pusher.WithAborts(pusher.Failures(5))),
+		}
+	)
+
+	got, err := pusher.Farm(pusher.Steady(100), duration, workers)
+
+	require.ErrorIs(t, err, pusher.ErrAborted)
+	assert.Less(t, got.Duration, duration/5)
+	assert.Positive(t, obs.received.Load())
+}
@@ -0,0 +1,55 @@
+package pusher
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"time"
+)
+
+// Arrival turns the mean interval between two ticks into the actual one,
+// so it defines the arrival process of the load. It receives the random
+// source of the Worker, so the runs with the same seed are reproducible.
+type Arrival func(mean time.Duration, rnd *rand.Rand) time.Duration
+
+// Constant creates the Arrival with evenly spaced ticks, the default one.
+func Constant() Arrival {
+	return func(mean time.Duration, _ *rand.Rand) time.Duration {
+		return mean
+	}
+}
+
+// Poisson creates the Arrival of the Poisson process: the intervals are
+// exponentially distributed, like the requests of independent users are.
+func Poisson() Arrival {
+	return func(mean time.Duration, rnd *rand.Rand) time.Duration {
+		return time.Duration(rnd.ExpFloat64() * float64(mean))
+	}
+}
+
+// Jitter creates the Arrival with the intervals uniformly distributed
+// around the mean within the given share of it, e.g. 0.1 means ±10%.
+func Jitter(spread float64) Arrival {
+	return func(mean time.Duration, rnd *rand.Rand) time.Duration {
+		return time.Duration(float64(mean) * (1 + spread*(2*rnd.Float64()-1)))
+	}
+}
+
+// newRand creates the random source for the seed, zero seed means a random one.
+// The stream separates the sources created for the same seed.
+func newRand(seed, stream uint64) *rand.Rand {
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+
+	return rand.New(rand.NewPCG(seed, stream)) //nolint:gosec // it's a load, not a crypto
+}
+
+// stream returns the stream of the random source of the Worker shifted by the offset.
+// It's derived from the ident, so the workers of the same seed (e.g. of the Force)
+// don't fire at the same instants.
+func (w *Worker) stream(offset uint64) uint64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(w.ident))
+
+	return hash.Sum64() + offset
+}
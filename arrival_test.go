@@ -0,0 +1,119 @@
+package pusher_test
+
+import (
+	"context"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+const samples = 10_000
+
+func TestConstant(t *testing.T) {
+	t.Parallel()
+
+	rnd := rand.New(rand.NewPCG(1, 1))
+
+	got := pusher.Constant()(time.Second, rnd)
+
+	assert.Equal(t, time.Second, got)
+}
+
+func TestPoisson(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rnd     = rand.New(rand.NewPCG(1, 1))
+		arrival = pusher.Poisson()
+		total   time.Duration
+	)
+
+	for range samples {
+		got := arrival(time.Second, rnd)
+
+		assert.GreaterOrEqual(t, got, time.Duration(0))
+
+		total += got
+	}
+
+	assert.InEpsilon(t, float64(time.Second), float64(total/samples), 0.05)
+}
+
+func TestJitter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rnd     = rand.New(rand.NewPCG(1, 1))
+		arrival = pusher.Jitter(0.1)
+		total   time.Duration
+	)
+
+	for range samples {
+		got := arrival(time.Second, rnd)
+
+		assert.GreaterOrEqual(t, got, 900*time.Millisecond)
+		assert.LessOrEqual(t, got, 1100*time.Millisecond)
+
+		total += got
+	}
+
+	assert.InEpsilon(t, float64(time.Second), float64(total/samples), 0.01)
+}
+
+func TestWorkerWorkArrival(t *testing.T) {
+	t.Parallel()
+
+	intervals := func(t *testing.T, ident string, profile pusher.Profile, offers ...pusher.Offer) []time.Duration {
+		t.Helper()
+
+		var (
+			rec    = newRecorder()
+			worker = pusher.Hire(ident, noop(), append(offers, pusher.WithGossips(rec))...)
+		)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 500*time.Millisecond)
+		defer cancel()
+
+		_, err := worker.Work(ctx, profile)
+
+		require.NoError(t, err)
+
+		ticks := make(map[uint64]time.Time)
+		for _, gossip := range rec.Gossips() {
+			ticks[gossip.Seq] = gossip.Tick
+		}
+
+		require.NotEmpty(t, ticks)
+
+		gaps := make([]time.Duration, 0, len(ticks))
+		for seq := uint64(2); seq <= uint64(len(ticks)); seq++ {
+			gaps = append(gaps, ticks[seq].Sub(ticks[seq-1]))
+		}
+
+		return gaps
+	}
+
+	for _, profile := range []pusher.Profile{pusher.Steady(100), pusher.Ramp(100, 200, time.Second)} {
+		var (
+			one   = intervals(t, "#1", profile, pusher.WithArrival(pusher.Poisson()), pusher.WithSeed(42))
+			two   = intervals(t, "#1", profile, pusher.WithArrival(pusher.Poisson()), pusher.WithSeed(42))
+			other = intervals(t, "#1", profile, pusher.WithArrival(pusher.Poisson()), pusher.WithSeed(7))
+			peer  = intervals(t, "#2", profile, pusher.WithArrival(pusher.Poisson()), pusher.WithSeed(42))
+			size  = min(len(one), len(two), len(other), len(peer))
+		)
+
+		assert.Equal(t, one[:size], two[:size], "same seed, same arrivals")
+		assert.NotEqual(t, one[:size], other[:size], "different seed, different arrivals")
+		assert.NotEqual(t, one[:size], peer[:size], "different worker, different arrivals")
+	}
+
+	even := intervals(t, "", pusher.Steady(100), pusher.WithArrival(nil))
+	for _, gap := range even {
+		assert.Equal(t, 10*time.Millisecond, gap)
+	}
+}
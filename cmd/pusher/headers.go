@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+)
+
+// headers is the flag.Value that collects the repeated "Key: Value" headers.
+type headers http.Header
+
+func (h headers) String() string {
+	var buf bytes.Buffer
+
+	_ = http.Header(h).Write(&buf)
+
+	return buf.String()
+}
+
+func (h headers) Set(value string) error {
+	key, val, ok := strings.Cut(value, ":")
+	if !ok || strings.TrimSpace(key) == "" {
+		return errInvalidHeader.Reason(value)
+	}
+
+	http.Header(h).Add(strings.TrimSpace(key), strings.TrimSpace(val))
+
+	return nil
+}
+
+func (h headers) Clone() http.Header {
+	return http.Header(h).Clone()
+}
@@ -0,0 +1,256 @@
+// Command pusher is the HTTP load testing tool built on top of the pusher library.
+//
+// Usage:
+//
+//	pusher -url http://localhost:8080/ping -rate 100 -duration 1m
+//	pusher -url http://localhost:8080/items -method POST -header 'Content-Type: application/json' \
+//		-body item.json -rate 25/2s -duration 30s -overtime 50 -workers 4
+//	pusher -url http://localhost:8080/ping -rate 10 -duration 0 -requests 500
+//
+// The rate is either the requests per second ("100") or the requests per period ("1/5s").
+// Every worker makes the load at the full rate, so the total one is the rate × workers.
+// The run lasts for the duration or till every worker has made the requests,
+// the interrupt signal (Ctrl-C) stops it earlier. It prints the summary of the run
+// and exits with the non-zero code if the run has failed or was interrupted.
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/therenotomorrow/ex"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/httpx"
+	"github.com/therenotomorrow/pusher/stats"
+)
+
+const (
+	errMissingURL      = ex.Error("url is missing")
+	errInvalidRate     = ex.Error("invalid rate")
+	errInvalidHeader   = ex.Error("invalid header")
+	errInvalidWorkers  = ex.Error("invalid workers")
+	errInvalidDuration = ex.Error("invalid duration")
+	errInvalidRequests = ex.Error("invalid requests")
+)
+
+// exit codes of the command.
+const (
+	success = 0
+	failure = 1
+	misuse  = 2
+)
+
+const (
+	defaultTimeout  = 10 * time.Second
+	defaultDuration = 10 * time.Second
+	defaultRate     = "1"
+)
+
+// options are the parsed command-line flags.
+type options struct {
+	headers  headers
+	url      string
+	method   string
+	body     string
+	rate     pusher.Rate
+	timeout  time.Duration
+	duration time.Duration
+	overtime int
+	workers  int
+	requests int
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+
+	stop()
+	os.Exit(code)
+}
+
+// run is the whole command: it parses the arguments, makes the load till
+// the end of the run or of the context and prints the summary, returning the exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	opts, err := parse(args, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return success
+	}
+
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "pusher:", err)
+
+		return misuse
+	}
+
+	target, err := build(opts)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "pusher:", err)
+
+		return misuse
+	}
+
+	collector := stats.New()
+	offers := []pusher.Offer{pusher.WithGossips(collector)}
+
+	if opts.overtime > 0 {
+		offers = append(offers, pusher.WithOvertime(opts.overtime))
+	}
+
+	if opts.requests > 0 {
+		offers = append(offers, pusher.WithResults(opts.requests))
+	}
+
+	if opts.duration > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, opts.duration)
+		defer cancel()
+	}
+
+	workers := make([]*pusher.Worker, opts.workers)
+	for idx := range workers {
+		workers[idx] = pusher.Hire(fmt.Sprintf("worker #%d", idx+1), target, offers...)
+	}
+
+	report, err := pusher.FarmContext(ctx, opts.rate, workers)
+
+	summarize(stdout, opts, report, collector.Summary())
+
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "pusher:", err)
+
+		return failure
+	}
+
+	return success
+}
+
+// parse parses the command-line flags into the options.
+func parse(args []string, output io.Writer) (*options, error) {
+	var (
+		opts = &options{
+			headers:  make(headers),
+			url:      "",
+			method:   "",
+			body:     "",
+			rate:     pusher.Rate{Freq: 0, Per: 0},
+			timeout:  0,
+			duration: 0,
+			overtime: 0,
+			workers:  0,
+			requests: 0,
+		}
+		rate  string
+		flags = flag.NewFlagSet("pusher", flag.ContinueOnError)
+	)
+
+	flags.SetOutput(output)
+	flags.StringVar(&opts.url, "url", "", "the URL to send the requests to (required)")
+	flags.StringVar(&opts.method, "method", http.MethodGet, "the HTTP method of the requests")
+	flags.Var(opts.headers, "header", "the request header as 'Key: Value', may be repeated")
+	flags.StringVar(&opts.body, "body", "", "the file with the request body")
+	flags.DurationVar(&opts.timeout, "timeout", defaultTimeout, "the timeout of a single request")
+	flags.StringVar(&rate, "rate", defaultRate, "the rate of every worker: '100' per second or '1/5s' per period")
+	flags.DurationVar(&opts.duration, "duration", defaultDuration, "the duration of the run, zero means no limit")
+	flags.IntVar(&opts.requests, "requests", 0, "the amount of requests of every worker, zero means no limit")
+	flags.IntVar(&opts.overtime, "overtime", 0, "the limit of concurrent requests, zero means no limit")
+	flags.IntVar(&opts.workers, "workers", 1, "the amount of workers at the full rate, the total is rate × workers")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // the flag package tells the reason itself
+	}
+
+	if opts.url == "" {
+		return nil, errMissingURL.Reason("use -url flag")
+	}
+
+	if opts.workers < 1 {
+		return nil, errInvalidWorkers.Reason("use -workers flag with a positive number")
+	}
+
+	if opts.duration < 0 {
+		return nil, errInvalidDuration.Reason("use -duration flag with a non-negative duration")
+	}
+
+	if opts.requests < 0 {
+		return nil, errInvalidRequests.Reason("use -requests flag with a non-negative number")
+	}
+
+	opts.rate, err = parseRate(rate)
+	if err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// build creates the HTTP Target from the options.
+func build(opts *options) (pusher.Target, error) {
+	var body []byte
+
+	if opts.body != "" {
+		content, err := os.ReadFile(opts.body)
+		if err != nil {
+			return nil, ex.Conv(err)
+		}
+
+		body = content
+	}
+
+	template, err := http.NewRequestWithContext(context.Background(), opts.method, opts.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, ex.Conv(err)
+	}
+
+	template.Header = opts.headers.Clone()
+
+	return httpx.New(template, httpx.WithTimeout(opts.timeout)), nil
+}
+
+// parseRate parses the rate like "100" (per second) or "25/2s".
+func parseRate(value string) (pusher.Rate, error) {
+	freq, per, found := strings.Cut(value, "/")
+
+	amount, err := strconv.Atoi(freq)
+	if err != nil || amount < 1 {
+		return pusher.Rate{}, errInvalidRate.Reason(value)
+	}
+
+	if !found {
+		return pusher.Steady(amount), nil
+	}
+
+	period, err := time.ParseDuration(per)
+	if err != nil || period <= 0 {
+		return pusher.Rate{}, errInvalidRate.Reason(value)
+	}
+
+	return pusher.Rate{Freq: amount, Per: period}, nil
+}
+
+// summarize prints the summary of the run.
+func summarize(output io.Writer, opts *options, report pusher.Report, summary stats.Summary) {
+	lines := []string{
+		fmt.Sprintf("target:     %s %s", opts.method, opts.url),
+		fmt.Sprintf("load:       %s per worker for %s, workers: %d", opts.rate, opts.duration, opts.workers),
+		fmt.Sprintf("requests:   %s", report),
+		fmt.Sprintf("latency:    min: %s, mean: %s, p50: %s, p90: %s, p99: %s, max: %s",
+			summary.Min, summary.Mean, summary.P50, summary.P90, summary.P99, summary.Max),
+		fmt.Sprintf("error rate: %.2f%%", summary.ErrorRate*100), //nolint:mnd // percents
+	}
+
+	_, _ = fmt.Fprintln(output, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+func TestParseRate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err   error
+		name  string
+		value string
+		want  pusher.Rate
+	}{
+		{name: "per second", value: "100", want: pusher.Steady(100), err: nil},
+		{name: "per period", value: "25/2s", want: pusher.Rate{Freq: 25, Per: 2 * time.Second}, err: nil},
+		{name: "not a number", value: "many", want: pusher.Rate{}, err: errInvalidRate},
+		{name: "zero", value: "0", want: pusher.Rate{}, err: errInvalidRate},
+		{name: "bad period", value: "1/forever", want: pusher.Rate{}, err: errInvalidRate},
+		{name: "negative period", value: "1/-1s", want: pusher.Rate{}, err: errInvalidRate},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseRate(test.value)
+
+			require.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	t.Parallel()
+
+	got := make(headers)
+
+	require.NoError(t, got.Set("Content-Type: application/json"))
+	require.NoError(t, got.Set("X-Trace:  abc:def "))
+	require.ErrorIs(t, got.Set("broken"), errInvalidHeader)
+	require.ErrorIs(t, got.Set(": value"), errInvalidHeader)
+
+	assert.Equal(t, "application/json", got.Clone().Get("Content-Type"))
+	assert.Equal(t, "abc:def", got.Clone().Get("X-Trace"))
+	assert.Contains(t, got.String(), "Content-Type: application/json")
+}
+
+func TestRunUsage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want string
+		args []string
+		code int
+	}{
+		{name: "help", args: []string{"-h"}, code: success, want: "Usage of pusher"},
+		{name: "missing url", args: []string{}, code: misuse, want: "url is missing"},
+		{
+			name: "invalid rate",
+			args: []string{"-url", "http://localhost", "-rate", "fast"},
+			code: misuse,
+			want: "invalid rate",
+		},
+		{name: "unknown flag", args: []string{"-unknown"}, code: misuse, want: "not defined"},
+		{
+			name: "negative workers",
+			args: []string{"-url", "http://localhost", "-workers", "-1"},
+			code: misuse,
+			want: "invalid workers",
+		},
+		{
+			name: "zero workers",
+			args: []string{"-url", "http://localhost", "-workers", "0"},
+			code: misuse,
+			want: "invalid workers",
+		},
+		{
+			name: "negative requests",
+			args: []string{"-url", "http://localhost", "-requests", "-1"},
+			code: misuse,
+			want: "invalid requests",
+		},
+		{
+			name: "negative duration",
+			args: []string{"-url", "http://localhost", "-duration", "-1s"},
+			code: misuse,
+			want: "invalid duration",
+		},
+		{
+			name: "invalid method",
+			args: []string{"-url", "http://localhost", "-method", "NO PE"},
+			code: misuse,
+			want: "invalid method",
+		},
+		{
+			name: "missing body",
+			args: []string{"-url", "http://localhost", "-body", "nowhere"},
+			code: misuse,
+			want: "nowhere",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			code := run(t.Context(), test.args, &stdout, &stderr)
+
+			assert.Equal(t, test.code, code)
+			assert.Contains(t, stderr.String(), test.want)
+			assert.Empty(t, stdout.String())
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		calls.Add(1)
+
+		body, _ := io.ReadAll(request.Body)
+
+		if request.Method != http.MethodPost || request.Header.Get("X-Token") != "secret" || string(body) != "ping" {
+			writer.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		_, _ = writer.Write([]byte("pong"))
+	}))
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "body.txt")
+	require.NoError(t, os.WriteFile(file, []byte("ping"), 0o600))
+
+	var stdout, stderr bytes.Buffer
+
+	code := run(t.Context(), []string{
+		"-url", server.URL,
+		"-method", http.MethodPost,
+		"-header", "X-Token: secret",
+		"-body", file,
+		"-rate", "25",
+		"-duration", "500ms",
+		"-workers", "2",
+		"-overtime", "10",
+	}, &stdout, &stderr)
+
+	assert.Equal(t, success, code)
+	assert.Empty(t, stderr.String())
+	assert.InDelta(t, 24, calls.Load(), 2)
+
+	output := stdout.String()
+
+	assert.Contains(t, output, "target:     POST "+server.URL)
+	assert.Contains(t, output, "load:       25/1s per worker for 500ms, workers: 2")
+	assert.Contains(t, output, "requests:   scheduled: ")
+	assert.Contains(t, output, "latency:    min: ")
+}
+
+func TestRunFailure(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+
+	code := run(t.Context(), []string{"-url", server.URL, "-rate", "20", "-duration", "200ms"}, &stdout, &stderr)
+
+	assert.Equal(t, success, code)
+	assert.Contains(t, stdout.String(), "error rate: 100.00%")
+}
+
+func TestRunRequests(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+
+	code := run(t.Context(), []string{
+		"-url", server.URL, "-rate", "100", "-duration", "0", "-requests", "5", "-workers", "2",
+	}, &stdout, &stderr)
+
+	assert.Equal(t, success, code)
+	assert.Empty(t, stderr.String())
+	assert.Equal(t, int64(10), calls.Load())
+	assert.Contains(t, stdout.String(), "completed: 10")
+}
+
+func TestRunInterrupted(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+
+	// the signal cancels the context of the run
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	code := run(ctx, []string{"-url", server.URL, "-rate", "20", "-duration", "0"}, &stdout, &stderr)
+
+	assert.Equal(t, failure, code)
+	assert.Contains(t, stderr.String(), "context canceled")
+	assert.Contains(t, stdout.String(), "requests:   scheduled: ")
+}
@@ -1,27 +1,53 @@
 package pusher
 
+import "time"
+
 const (
 	// double is a multiplier for the listener channel's buffer size.
 	// A size of 2*rps provides a sufficient buffer to handle bursts
 	// of BeforeTarget and AfterTarget events without blocking.
+	// For the Profile the highest rate over the run is used, up to a limit.
 	double          = 2
 	defaultIdent    = "judas"
 	defaultOvertime = 1_000_000
+	defaultGrace    = 5 * time.Second
 )
 
 type (
 	config struct {
-		listeners []Gossiper
-		overtime  int
+		arrival      Arrival
+		thinking     Arrival
+		listeners    []Gossiper
+		thresholds   []Threshold
+		aborts       []Abort
+		scenarios    []Scenario
+		interceptors []Interceptor
+		overtime     int
+		seed         uint64
+		think        time.Duration
+		grace        time.Duration
+		iterations   int
+		tasks        int
+		results      int
 	}
 
 	// Config is a public copy of the Worker internals.
 	Config struct {
-		Ident       string
-		Listeners   []Gossiper
-		Overtime    int
-		WLBCapacity int
-		Busy        bool
+		Ident        string
+		Listeners    []Gossiper
+		Thresholds   []Threshold
+		Aborts       []Abort
+		Scenarios    []Scenario
+		Interceptors []Interceptor
+		Overtime     int
+		WLBCapacity  int
+		Seed         uint64
+		Think        time.Duration
+		Grace        time.Duration
+		Iterations   int
+		Tasks        int
+		Results      int
+		Busy         bool
 	}
 
 	// Offer is a functional option for configuring a Worker.
@@ -44,13 +70,139 @@ func WithOvertime(limit int) Offer {
 	}
 }
 
+// WithArrival sets the arrival process of the load: how the ticks are spread
+// around the intervals given by the Profile. The default one is Constant.
+func WithArrival(arrival Arrival) Offer {
+	return func(w *Worker) {
+		if arrival == nil {
+			arrival = Constant()
+		}
+
+		w.config.arrival = arrival
+	}
+}
+
+// WithSeed sets the seed of the Worker random source, so the runs with
+// the same seed are reproducible. Zero seed means a random one, the default.
+// The source also depends on the ident of the Worker, so the workers hired with
+// the same seed and different idents (e.g. by the Force) make different arrivals.
+func WithSeed(seed uint64) Offer {
+	return func(w *Worker) {
+		w.config.seed = seed
+	}
+}
+
+// WithThink sets the think time of the Crew virtual users: the pause between
+// two calls of the Target by the same user. The pauses are drawn from
+// the Arrival around the mean, there is no think time by default.
+func WithThink(mean time.Duration, arrival Arrival) Offer {
+	return func(w *Worker) {
+		if arrival == nil {
+			arrival = Constant()
+		}
+
+		w.config.think = mean
+		w.config.thinking = arrival
+	}
+}
+
+// WithGrace sets the time the running tasks get to finish after the planned end
+// of the run (the elapsed deadline of the context), 5 seconds by default. Only
+// the schedule stops at the planned end, so the tasks in flight are not failed
+// by it and their events reach the listeners. The tasks still running after the
+// grace period are canceled, as well as all of them at once when the run is
+// canceled or aborted.
+func WithGrace(grace time.Duration) Offer {
+	return func(w *Worker) {
+		w.config.grace = max(grace, 0)
+	}
+}
+
+// WithIterations limits the amount of the Target calls made by every Crew
+// virtual user. Zero or negative limit means no limit, the default.
+func WithIterations(limit int) Offer {
+	return func(w *Worker) {
+		w.config.iterations = limit
+	}
+}
+
+// WithTasks stops the Worker after the given amount of scheduled tasks,
+// including the Canceled ones. The Worker waits for the started tasks
+// and returns nil. Zero or negative amount means no limit, the default.
+// The Crew has no schedule and ignores it, use WithIterations or WithResults.
+func WithTasks(amount int) Offer {
+	return func(w *Worker) {
+		w.config.tasks = amount
+	}
+}
+
+// WithResults stops the Worker after the given amount of completed tasks:
+// the Worker stops to schedule new tasks as soon as this amount is started,
+// waits for them and returns nil. Canceled tasks are not counted.
+// For the Crew it's the total amount for all the virtual users.
+// Zero or negative amount means no limit, the default.
+func WithResults(amount int) Offer {
+	return func(w *Worker) {
+		w.config.results = amount
+	}
+}
+
+// WithThresholds sets the pass criteria of the run (SLO gates), e.g.
+// the p99 latency under 300ms or the error rate under 1%. The thresholds
+// are checked when the work ends as planned: Work returns ErrThresholdBreached
+// listing every breached one, so the run may fail the CI build.
+func WithThresholds(thresholds ...Threshold) Offer {
+	return func(w *Worker) {
+		w.config.thresholds = thresholds
+	}
+}
+
+// WithAborts sets the rules that stop the run early when the Target is clearly
+// broken: too many errors within a sliding window, failures in a row or a too
+// slow task. The tripped rule cancels the Worker (and the whole Farm), so Work
+// returns ErrAborted telling the reason.
+func WithAborts(aborts ...Abort) Offer {
+	return func(w *Worker) {
+		w.config.aborts = aborts
+	}
+}
+
+// WithMix splits the load of the Worker between the named Targets in proportion
+// to their weights, e.g. 70% browse, 25% search and 5% checkout of the total rate.
+// Every task picks its Scenario at random (see WithSeed) and every Gossip
+// of the task tells its name. The Target given to Hire is ignored, so it may be nil.
+func WithMix(scenarios ...Scenario) Offer {
+	return func(w *Worker) {
+		w.config.scenarios = scenarios
+	}
+}
+
+// WithInterceptors wraps every call of the Target by the interceptors, the first
+// one is the outermost. They run within the task, so the context they derive
+// (e.g. with the span of the task) is the one the Target gets.
+func WithInterceptors(interceptors ...Interceptor) Offer {
+	return func(w *Worker) {
+		w.config.interceptors = interceptors
+	}
+}
+
 // Config returns the public copy of Worker internals.
 func (w *Worker) Config() Config {
 	return Config{
-		Busy:        w.busy.Load(),
-		Ident:       w.ident,
-		Listeners:   w.config.listeners,
-		Overtime:    w.config.overtime,
-		WLBCapacity: cap(w.wlb),
+		Busy:         w.busy.Load(),
+		Ident:        w.ident,
+		Listeners:    w.config.listeners,
+		Thresholds:   w.config.thresholds,
+		Aborts:       w.config.aborts,
+		Scenarios:    w.config.scenarios,
+		Interceptors: w.config.interceptors,
+		Overtime:     w.config.overtime,
+		WLBCapacity:  cap(w.wlb),
+		Seed:         w.config.seed,
+		Think:        w.config.think,
+		Grace:        w.config.grace,
+		Iterations:   w.config.iterations,
+		Tasks:        w.config.tasks,
+		Results:      w.config.results,
 	}
 }
@@ -1,7 +1,9 @@
 package pusher_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -74,6 +76,181 @@ func TestWithOvertime(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestWithSeed(t *testing.T) {
+	t.Parallel()
+
+	var (
+		seed   = uint64(42)
+		worker = new(pusher.Worker)
+	)
+
+	pusher.WithSeed(seed)(worker)
+
+	got := worker.Config().Seed
+	want := seed
+
+	assert.Equal(t, want, got)
+}
+
+func TestWithThink(t *testing.T) {
+	t.Parallel()
+
+	var (
+		think  = time.Second
+		worker = new(pusher.Worker)
+	)
+
+	pusher.WithThink(think, pusher.Poisson())(worker)
+
+	got := worker.Config().Think
+	want := think
+
+	assert.Equal(t, want, got)
+}
+
+func TestWithGrace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		grace time.Duration
+		want  time.Duration
+	}{
+		{name: "positive", grace: time.Minute, want: time.Minute},
+		{name: "negative", grace: -time.Minute, want: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			worker := new(pusher.Worker)
+
+			pusher.WithGrace(test.grace)(worker)
+
+			assert.Equal(t, test.want, worker.Config().Grace)
+		})
+	}
+}
+
+func TestWithIterations(t *testing.T) {
+	t.Parallel()
+
+	var (
+		limit  = 42
+		worker = new(pusher.Worker)
+	)
+
+	pusher.WithIterations(limit)(worker)
+
+	got := worker.Config().Iterations
+	want := limit
+
+	assert.Equal(t, want, got)
+}
+
+func TestWithTasks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		amount = 42
+		worker = new(pusher.Worker)
+	)
+
+	pusher.WithTasks(amount)(worker)
+
+	got := worker.Config().Tasks
+	want := amount
+
+	assert.Equal(t, want, got)
+}
+
+func TestWithResults(t *testing.T) {
+	t.Parallel()
+
+	var (
+		amount = 42
+		worker = new(pusher.Worker)
+	)
+
+	pusher.WithResults(amount)(worker)
+
+	got := worker.Config().Results
+	want := amount
+
+	assert.Equal(t, want, got)
+}
+
+func TestWithThresholds(t *testing.T) {
+	t.Parallel()
+
+	var (
+		worker     = new(pusher.Worker)
+		thresholds = []pusher.Threshold{pusher.ErrorRate(0.01), pusher.Throughput(10)}
+	)
+
+	pusher.WithThresholds(thresholds...)(worker)
+
+	got := worker.Config().Thresholds
+
+	assert.Len(t, got, len(thresholds))
+}
+
+func TestWithAborts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		worker = new(pusher.Worker)
+		aborts = []pusher.Abort{pusher.Failures(5), pusher.Ceiling(time.Second)}
+	)
+
+	pusher.WithAborts(aborts...)(worker)
+
+	got := worker.Config().Aborts
+
+	assert.Len(t, got, len(aborts))
+}
+
+func TestWithMix(t *testing.T) {
+	t.Parallel()
+
+	var (
+		worker    = new(pusher.Worker)
+		scenarios = []pusher.Scenario{
+			{Target: noop(), Name: "browse", Weight: 70},
+			{Target: noop(), Name: "search", Weight: 30},
+		}
+	)
+
+	pusher.WithMix(scenarios...)(worker)
+
+	got := worker.Config().Scenarios
+
+	assert.Len(t, got, len(scenarios))
+}
+
+func TestWithInterceptors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		worker      = new(pusher.Worker)
+		interceptor = func(
+			ctx context.Context,
+			_ *pusher.Worker,
+			_ *pusher.Gossip,
+			call pusher.Target,
+		) (pusher.Result, error) {
+			return call(ctx)
+		}
+	)
+
+	pusher.WithInterceptors(interceptor, interceptor)(worker)
+
+	got := worker.Config().Interceptors
+
+	assert.Len(t, got, 2)
+}
+
 func TestWorkerConfig(t *testing.T) {
 	t.Parallel()
 
@@ -86,11 +263,21 @@ func TestWorkerConfig(t *testing.T) {
 
 	got := worker.Config()
 	want := pusher.Config{
-		Ident:       ident,
-		Listeners:   gossipers,
-		Overtime:    limit,
-		Busy:        false,
-		WLBCapacity: limit,
+		Ident:        ident,
+		Listeners:    gossipers,
+		Thresholds:   make([]pusher.Threshold, 0),
+		Aborts:       make([]pusher.Abort, 0),
+		Scenarios:    make([]pusher.Scenario, 0),
+		Interceptors: make([]pusher.Interceptor, 0),
+		Overtime:     limit,
+		Busy:         false,
+		WLBCapacity:  limit,
+		Seed:         0,
+		Think:        0,
+		Grace:        5 * time.Second,
+		Iterations:   0,
+		Tasks:        0,
+		Results:      0,
 	}
 
 	assert.Equal(t, want, got)
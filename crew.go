@@ -0,0 +1,154 @@
+package pusher
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Crew starts the closed model load: each of the given amount of virtual users
+// calls the Target, thinks (see WithThink) and calls it again. It's a blocking
+// method that runs until the provided context is done or all the users
+// reach the iterations limit (see WithIterations) or the quota (see WithResults).
+// Like Work, it returns the Report of the run and nil error when the deadline
+// of the context elapses and the run passes the thresholds.
+// The events are the same as for Work, except there are no Canceled ones.
+func (w *Worker) Crew(ctx context.Context, users int) (Report, error) {
+	err := w.validateCrew(users)
+	if err != nil {
+		return Report{}, err
+	}
+
+	defer w.busy.Store(false)
+
+	ctx, abort := context.WithCancelCause(ctx)
+	defer abort(nil)
+
+	tasks, release := w.linger(ctx)
+	defer release()
+
+	var (
+		count  = newTally(newGuard(w.config.aborts, abort))
+		tracks = w.runListeners(ctx, double*users)
+	)
+
+	err = w.gather(ctx, tasks, users, tracks, count)
+
+	w.complete(tracks, count)
+
+	if err == nil {
+		err = w.assess(count)
+	}
+
+	return count.report(w.ident), err
+}
+
+// gather runs the virtual users of Crew, it returns when all of them are done
+// or the context is. The calls are made within the context of the tasks, see linger.
+func (w *Worker) gather(ctx, tasks context.Context, users int, tracks []chan *Gossip, count *tally) error {
+	var (
+		seq  atomic.Uint64
+		crew sync.WaitGroup
+		done = make(chan struct{})
+	)
+
+	for user := range users {
+		rnd := newRand(w.config.seed, w.stream(uint64(user))) //nolint:gosec // user is never negative
+
+		crew.Add(1)
+		w.wait.Go(func() {
+			defer crew.Done()
+
+			w.live(ctx, tasks, tracks, count, &seq, rnd)
+		})
+	}
+
+	go func() {
+		crew.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return finish(ctx)
+	case <-done:
+		return nil
+	}
+}
+
+// live is the life of a single virtual user: target, think, target and so on.
+func (w *Worker) live(
+	ctx, tasks context.Context,
+	tracks []chan *Gossip,
+	count *tally,
+	seq *atomic.Uint64,
+	rnd *rand.Rand,
+) {
+	var (
+		blend    = w.newMix()
+		thinking = time.NewTimer(0)
+	)
+
+	defer thinking.Stop()
+
+	for iteration := 0; w.config.iterations < 1 || iteration < w.config.iterations; iteration++ {
+		if ctx.Err() != nil {
+			return
+		}
+
+		// the sequence is shared by all the users, so it counts the results too
+		num := seq.Add(1)
+		if w.config.results > 0 && num > uint64(w.config.results) {
+			return
+		}
+
+		count.scheduled.Add(1)
+		count.started.Add(1)
+
+		scenario := blend.pick(rnd)
+		w.execute(tasks, tracks, count, scenario.Target, Gossip{
+			Result:   nil,
+			Error:    nil,
+			Tick:     time.Now(),
+			Start:    time.Time{},
+			End:      time.Time{},
+			When:     Canceled,
+			Scenario: scenario.Name,
+			Step:     "",
+			Seq:      num,
+		})
+
+		// no pause after the last call, the user is done
+		if w.last(iteration, seq) {
+			return
+		}
+
+		thinking.Reset(max(w.config.thinking(w.config.think, rnd), 0))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-thinking.C:
+		}
+	}
+}
+
+// last tells whether the user reached the iterations limit or the quota is used up.
+func (w *Worker) last(iteration int, seq *atomic.Uint64) bool {
+	if w.config.iterations > 0 && iteration+1 >= w.config.iterations {
+		return true
+	}
+
+	return w.config.results > 0 && seq.Load() >= uint64(w.config.results)
+}
+
+// validateCrew performs pre-flight checks before starting the virtual users.
+func (w *Worker) validateCrew(users int) error {
+	if users < 1 {
+		return ErrInvalidUsers.Reason("must be positive")
+	}
+
+	return w.occupy()
+}
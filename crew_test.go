@@ -0,0 +1,168 @@
+package pusher_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+func TestWorkerCrewValidate(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		target pusher.Target
+		users  int
+	}
+
+	type want struct {
+		err  error
+		text string
+	}
+
+	tests := []struct {
+		args args
+		want want
+		name string
+	}{
+		{
+			name: "target",
+			args: args{target: nil, users: 1},
+			want: want{err: pusher.ErrMissingTarget, text: "target is missing: not provided"},
+		},
+		{
+			name: "zero",
+			args: args{target: noop(), users: 0},
+			want: want{err: pusher.ErrInvalidUsers, text: "invalid users: must be positive"},
+		},
+		{
+			name: "negative",
+			args: args{target: noop(), users: -42},
+			want: want{err: pusher.ErrInvalidUsers, text: "invalid users: must be positive"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			worker := pusher.Hire("", test.args.target)
+
+			_, err := worker.Crew(t.Context(), test.args.users)
+
+			require.ErrorIs(t, err, test.want.err)
+			require.EqualError(t, err, test.want.text)
+			assert.False(t, worker.Config().Busy)
+		})
+	}
+}
+
+func TestWorkerCrewIterations(t *testing.T) {
+	t.Parallel()
+
+	var (
+		users      = 3
+		iterations = 5
+		rec        = newRecorder()
+		worker     = pusher.Hire("", noop(), pusher.WithGossips(rec), pusher.WithIterations(iterations))
+	)
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Minute)
+	defer cancel()
+
+	_, err := worker.Crew(ctx, users)
+
+	require.NoError(t, err)
+	assert.False(t, worker.Config().Busy)
+
+	seqs := make(map[uint64]bool)
+
+	for _, gossip := range rec.Gossips() {
+		assert.False(t, gossip.Canceled())
+
+		if gossip.AfterTarget() {
+			seqs[gossip.Seq] = true
+		}
+	}
+
+	assert.Len(t, seqs, users*iterations)
+}
+
+func TestWorkerCrewThink(t *testing.T) {
+	t.Parallel()
+
+	var (
+		users = 2
+		think = 100 * time.Millisecond
+		obs   = newObserver()
+	)
+
+	_, err := pusher.Crew(users, time.Second, noop(), pusher.WithGossips(obs), pusher.WithThink(think, pusher.Jitter(0.1)))
+
+	require.NoError(t, err)
+	assert.InDelta(t, users*10, obs.received.Load(), 2)
+	assert.Equal(t, obs.received.Load(), obs.success.Load())
+}
+
+func TestWorkerCrewCanceled(t *testing.T) {
+	t.Parallel()
+
+	var (
+		users  = 10
+		obs    = newObserver()
+		worker = pusher.Hire("", awaitable(), pusher.WithGossips(obs))
+	)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := worker.Crew(ctx, users)
+
+	require.NoError(t, err)
+	assert.False(t, worker.Config().Busy)
+	assert.Equal(t, int64(users), obs.received.Load())
+}
+
+func TestWorkerCrewResults(t *testing.T) {
+	t.Parallel()
+
+	var (
+		users   = 3
+		results = 7
+		obs     = newObserver()
+	)
+
+	_, err := pusher.Crew(users, 0, noop(), pusher.WithGossips(obs), pusher.WithResults(results))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(results), obs.success.Load())
+}
+
+func TestWorkerCrewLastThink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		offer pusher.Offer
+		name  string
+	}{
+		{name: "iterations", offer: pusher.WithIterations(1)},
+		{name: "results", offer: pusher.WithResults(1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			start := time.Now()
+			report, err := pusher.Crew(1, 0, noop(), test.offer, pusher.WithThink(time.Minute, nil))
+
+			require.NoError(t, err)
+			assert.Less(t, time.Since(start), time.Second)
+			assert.Equal(t, uint64(1), report.Completed)
+		})
+	}
+}
@@ -5,7 +5,7 @@ import (
 )
 
 const (
-	// ErrInvalidRPS is returned when the provided requests-per-second (RPS) value is not positive.
+	// ErrInvalidRPS is returned when the provided Rate is not positive or too large.
 	ErrInvalidRPS = ex.Error("invalid rps")
 
 	// ErrMissingTarget is returned when a Worker is hired without a Target function.
@@ -14,6 +14,31 @@ const (
 	// ErrWorkerIsBusy is returned when Work is called on a Worker that is already running.
 	ErrWorkerIsBusy = ex.Error("worker is busy")
 
+	// ErrMissingProfile is returned when Work is called without a Profile.
+	ErrMissingProfile = ex.Error("profile is missing")
+
+	// ErrInvalidProfile is returned when Work is called with a Profile built of the invalid
+	// arguments, e.g. Steps with a non-positive time or Stages with a stage without the Profile.
+	ErrInvalidProfile = ex.Error("invalid profile")
+
+	// ErrInvalidUsers is returned when Crew is called with a non-positive amount of users.
+	ErrInvalidUsers = ex.Error("invalid users")
+
 	// ErrInvalidOvertime is returned when Work is tried to run with a negative WithOvertime option.
 	ErrInvalidOvertime = ex.Error("invalid overtime")
+
+	// ErrThresholdBreached is returned when the finished run doesn't pass the thresholds
+	// given by WithThresholds, the reason lists every breached one.
+	ErrThresholdBreached = ex.Error("threshold is breached")
+
+	// ErrAborted is returned when the run is stopped early by the rules given
+	// by WithAborts, the reason tells which rule has tripped.
+	ErrAborted = ex.Error("run is aborted")
+
+	// ErrInvalidMix is returned when Work is tried to run with a Scenario given by WithMix
+	// that has no Target or a non-positive weight.
+	ErrInvalidMix = ex.Error("invalid mix")
+
+	// ErrFeedExhausted is returned by the Feeder that has no more records to give.
+	ErrFeedExhausted = ex.Error("feed is exhausted")
 )
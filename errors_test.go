@@ -26,8 +26,44 @@ func TestErrWorkerIsBusy(t *testing.T) {
 	assert.EqualError(t, pusher.ErrWorkerIsBusy, "worker is busy")
 }
 
+func TestErrMissingProfile(t *testing.T) {
+	t.Parallel()
+
+	assert.EqualError(t, pusher.ErrMissingProfile, "profile is missing")
+}
+
+func TestErrInvalidProfile(t *testing.T) {
+	t.Parallel()
+
+	assert.EqualError(t, pusher.ErrInvalidProfile, "invalid profile")
+}
+
 func TestErrInvalidOvertime(t *testing.T) {
 	t.Parallel()
 
 	assert.EqualError(t, pusher.ErrInvalidOvertime, "invalid overtime")
 }
+
+func TestErrThresholdBreached(t *testing.T) {
+	t.Parallel()
+
+	assert.EqualError(t, pusher.ErrThresholdBreached, "threshold is breached")
+}
+
+func TestErrAborted(t *testing.T) {
+	t.Parallel()
+
+	assert.EqualError(t, pusher.ErrAborted, "run is aborted")
+}
+
+func TestErrFeedExhausted(t *testing.T) {
+	t.Parallel()
+
+	assert.EqualError(t, pusher.ErrFeedExhausted, "feed is exhausted")
+}
+
+func TestErrInvalidMix(t *testing.T) {
+	t.Parallel()
+
+	assert.EqualError(t, pusher.ErrInvalidMix, "invalid mix")
+}
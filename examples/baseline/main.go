@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/examples"
+	"github.com/therenotomorrow/pusher/report"
+)
+
+func main() {
+	recorder := report.NewRecorder()
+
+	rps := 50
+	duration := 10 * time.Second
+
+	log.Println(pusher.Work(pusher.Steady(rps), duration, examples.RandomTime, pusher.WithGossips(recorder)))
+
+	current := recorder.Run("RandomTime, " + time.Now().Format(time.DateTime))
+
+	file, err := os.Open("baseline.json")
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		// The first run becomes the baseline of the next ones
+		file, err = os.Create("baseline.json")
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		defer func() { _ = file.Close() }()
+
+		log.Println(report.Save(file, current))
+	case err != nil:
+		log.Fatalln(err)
+	default:
+		defer func() { _ = file.Close() }()
+
+		baseline, err := report.Load(file)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		// The run may be 20% slower at p99 and fail 1% of the tasks more
+		comparison, err := report.Compare(baseline, current,
+			report.Slower(report.MetricP99, 0.2),
+			report.MoreErrors(0.01),
+		)
+
+		log.Println(report.Diff(os.Stdout, comparison))
+		log.Println(err)
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/examples"
+)
+
+func main() {
+	users := 20
+	duration := time.Minute
+	think := 2 * time.Second
+	iterations := 10
+
+	// Run 20 users, each makes 10 calls with ~2 seconds to think between them
+	log.Println(pusher.Crew(users, duration, examples.RandomTime,
+		pusher.WithThink(think, pusher.Poisson()),
+		pusher.WithIterations(iterations),
+	))
+}
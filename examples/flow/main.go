@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/examples"
+	"github.com/therenotomorrow/pusher/stats"
+)
+
+func main() {
+	steps := []pusher.Step{
+		{Name: "login", Run: func(_ context.Context, state pusher.State) (pusher.Result, error) {
+			state["token"] = "secret"
+
+			return examples.Str("logged in"), nil
+		}},
+		{Name: "list", Run: func(ctx context.Context, _ pusher.State) (pusher.Result, error) {
+			return examples.RandomTime(ctx)
+		}},
+		{Name: "logout", Run: func(_ context.Context, state pusher.State) (pusher.Result, error) {
+			token, _ := state["token"].(string)
+
+			return examples.Str("logged out " + token), nil
+		}},
+	}
+
+	// One collector per step gives the statistics of the step
+	collectors := make(map[string]*stats.Collector)
+	gossipers := make([]pusher.Gossiper, 0)
+
+	for _, step := range steps {
+		collectors[step.Name] = stats.New(stats.WithStep(step.Name))
+		gossipers = append(gossipers, collectors[step.Name])
+	}
+
+	rps := 10
+	duration := time.Minute
+
+	// Run the login → list → logout journey 10 times per second
+	log.Println(pusher.Work(pusher.Steady(rps), duration, pusher.Flow(steps...), pusher.WithGossips(gossipers...)))
+
+	for _, step := range steps {
+		log.Printf("%s: %+v\n", step.Name, collectors[step.Name].Summary())
+	}
+}
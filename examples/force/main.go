@@ -14,7 +14,7 @@ func main() {
 	amount := 5
 
 	// Create a runner with the set pre-requests
-	runner := pusher.Force(rps, duration, examples.Target)
+	runner := pusher.Force(pusher.Steady(rps), duration, examples.Target)
 
 	// Create 10 workers with the same configuration
 	log.Println(runner(amount))
@@ -20,5 +20,5 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), duration)
 	defer cancel()
 
-	log.Println(worker.Work(ctx, rps))
+	log.Println(worker.Work(ctx, pusher.Steady(rps)))
 }
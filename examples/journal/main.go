@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/examples"
+	"github.com/therenotomorrow/pusher/journal"
+	"github.com/therenotomorrow/pusher/stats"
+)
+
+func main() {
+	file, err := os.Create("run.jsonl")
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	writer := journal.New(file)
+
+	rps := 50
+	duration := 10 * time.Second
+
+	// Write every gossip of the run to the file
+	log.Println(pusher.Work(pusher.Steady(rps), duration, examples.RandomTime, pusher.WithGossips(writer)))
+	log.Println(writer.Err(), file.Close())
+
+	file, err = os.Open("run.jsonl")
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	// Recompute the statistics offline, e.g. measured from the intended start of the tasks
+	collector := stats.New(stats.WithScheduled())
+
+	log.Println(journal.Replay(context.Background(), file, collector))
+	log.Printf("%+v\n", collector.Summary())
+}
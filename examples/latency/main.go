@@ -0,0 +1,24 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/examples"
+	"github.com/therenotomorrow/pusher/stats"
+)
+
+func main() {
+	rps := 50
+	duration := time.Minute
+	collector := stats.New()
+
+	// Run with 50 RPS for one minute and measure latencies
+	log.Println(pusher.Work(pusher.Steady(rps), duration, examples.RandomTime, pusher.WithGossips(collector)))
+
+	summary := collector.Summary()
+
+	log.Printf("p50: %s, p99: %s, max: %s", summary.P50, summary.P99, summary.Max)
+	log.Printf("throughput: %.2f rps, errors: %.2f%%", summary.Throughput, summary.ErrorRate*100)
+}
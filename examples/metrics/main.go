@@ -79,7 +79,7 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), duration)
 	defer cancel()
 
-	log.Println(worker.Work(ctx, rps))
+	log.Println(worker.Work(ctx, pusher.Steady(rps)))
 
 	collector.Stop()
 
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/examples"
+	"github.com/therenotomorrow/pusher/stats"
+)
+
+func main() {
+	var (
+		targets   = examples.Targets()
+		scenarios = []pusher.Scenario{
+			{Target: targets[0], Name: "browse", Weight: 70},
+			{Target: targets[1], Name: "search", Weight: 25},
+			{Target: targets[2], Name: "checkout", Weight: 5},
+		}
+		collectors = make([]*stats.Collector, 0, len(scenarios))
+		gossipers  = make([]pusher.Gossiper, 0, len(scenarios))
+	)
+
+	// One collector per scenario gives the statistics per endpoint
+	for _, scenario := range scenarios {
+		collector := stats.New(stats.WithScenario(scenario.Name))
+		collectors = append(collectors, collector)
+		gossipers = append(gossipers, collector)
+	}
+
+	rps := 500
+	duration := time.Minute
+
+	// Run 70% browse, 25% search and 5% checkout at 500 RPS in total
+	log.Println(pusher.Work(pusher.Steady(rps), duration, nil,
+		pusher.WithMix(scenarios...),
+		pusher.WithGossips(gossipers...),
+	))
+
+	for idx, scenario := range scenarios {
+		log.Printf("%s: %+v\n", scenario.Name, collectors[idx].Summary())
+	}
+}
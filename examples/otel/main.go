@@ -0,0 +1,25 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/examples"
+	"github.com/therenotomorrow/pusher/otelx"
+)
+
+func main() {
+	// The spans and the metrics go to the global providers,
+	// set them up with the OpenTelemetry SDK and the exporters you like
+	interceptor, err := otelx.New()
+	if err != nil {
+		log.Println(err)
+	}
+
+	rps := 50
+	duration := time.Minute
+
+	// Run with 50 RPS for one minute, every task in its own span
+	log.Println(pusher.Work(pusher.Steady(rps), duration, examples.Target, pusher.WithInterceptors(interceptor)))
+}
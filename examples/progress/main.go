@@ -0,0 +1,21 @@
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/examples"
+	"github.com/therenotomorrow/pusher/progress"
+)
+
+func main() {
+	// Print the progress line every second, the p95 is measured over the last 5 seconds
+	printer := progress.New(os.Stderr, progress.WithWindow(5*time.Second))
+
+	rps := 50
+	duration := 30 * time.Second
+
+	log.Println(pusher.Work(pusher.Steady(rps), duration, examples.RandomTime, pusher.WithGossips(printer)))
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/examples"
+	"github.com/therenotomorrow/pusher/promx"
+)
+
+func main() {
+	exporter := promx.New()
+
+	// Scrape http://localhost:2112/metrics while the soak test runs
+	server := &http.Server{Addr: ":2112", Handler: exporter.Handler(), ReadHeaderTimeout: time.Second}
+
+	go func() {
+		log.Println(server.ListenAndServe())
+	}()
+
+	rps := 50
+	duration := time.Hour
+
+	// Soak with 50 RPS for one hour
+	log.Println(pusher.Work(pusher.Steady(rps), duration, examples.RandomTime, pusher.WithGossips(exporter)))
+}
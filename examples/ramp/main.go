@@ -0,0 +1,22 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/examples"
+)
+
+func main() {
+	duration := 3 * time.Minute
+
+	// Warm up for a minute, hold 100 RPS for a minute and cool down
+	profile := pusher.Stages(
+		pusher.Stage{Profile: pusher.Ramp(0, 100, time.Minute), Duration: time.Minute},
+		pusher.Stage{Profile: pusher.Steady(100), Duration: time.Minute},
+		pusher.Stage{Profile: pusher.Ramp(100, 0, time.Minute), Duration: time.Minute},
+	)
+
+	log.Println(pusher.Work(profile, duration, examples.Target))
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/examples"
+	"github.com/therenotomorrow/pusher/report"
+)
+
+func main() {
+	recorder := report.NewRecorder(report.WithInterval(500 * time.Millisecond))
+
+	rps := 50
+	duration := 10 * time.Second
+
+	ramp := pusher.Ramp(1, float64(rps), duration)
+
+	log.Println(pusher.Work(ramp, duration, examples.RandomTime, pusher.WithGossips(recorder)))
+
+	run := recorder.Run("RandomTime under the ramp")
+
+	// Print the tables to the terminal
+	log.Println(report.Markdown(os.Stdout, run))
+
+	file, err := os.Create("report.html")
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	// Render the page with the charts, open it in any browser
+	log.Println(report.HTML(file, run))
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/examples"
+	"github.com/therenotomorrow/pusher/series"
+)
+
+func main() {
+	// Split the soak test into the windows of 5 seconds
+	collector := series.New(series.WithInterval(5 * time.Second))
+
+	rps := 20
+	duration := time.Minute
+
+	log.Println(pusher.Work(pusher.Steady(rps), duration, examples.RandomTime, pusher.WithGossips(collector)))
+
+	for _, bucket := range collector.Buckets() {
+		log.Printf("%6s: %6.2f rps, %3d errors, p95 %s\n", bucket.Offset, bucket.Throughput, bucket.Failed, bucket.P95)
+	}
+
+	file, err := os.Create("series.csv")
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	log.Println(collector.WriteCSV(file))
+}
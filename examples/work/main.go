@@ -13,5 +13,5 @@ func main() {
 	duration := time.Minute
 
 	// Run with 50 RPS for one minute
-	log.Println(pusher.Work(rps, duration, examples.Target))
+	log.Println(pusher.Work(pusher.Steady(rps), duration, examples.Target))
 }
@@ -0,0 +1,170 @@
+package pusher
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/therenotomorrow/ex"
+)
+
+type (
+	// Feeder gives the record for the next task of the parameterised Target,
+	// see Feed. It must be safe for concurrent use.
+	Feeder[T any] func() (T, error)
+
+	// Strategy picks the index of the record for the task number num (from 0)
+	// among the given amount of records, false means the records are over.
+	// It's called under the lock of the Feeder, so it may keep its own state,
+	// but it mustn't be shared between the Feeders.
+	Strategy func(num uint64, amount int) (int, bool)
+)
+
+// Feed creates the Target that passes the next record of the feeder to the given
+// function. If the feeder fails (e.g. with ErrFeedExhausted), the function isn't
+// called and the task fails with the same error.
+func Feed[T any](feeder Feeder[T], target func(ctx context.Context, record T) (Result, error)) Target {
+	if feeder == nil || target == nil {
+		return nil
+	}
+
+	return func(ctx context.Context) (Result, error) {
+		record, err := feeder()
+		if err != nil {
+			return nil, err
+		}
+
+		return target(ctx, record)
+	}
+}
+
+// Sequential creates the Strategy that gives the records one by one and starts
+// over after the last one, the usual choice.
+func Sequential() Strategy {
+	return func(num uint64, amount int) (int, bool) {
+		return int(num % uint64(amount)), true //nolint:gosec // amount is positive, so is the index
+	}
+}
+
+// Random creates the Strategy that gives a random record for every task,
+// the runs with the same non-zero seed are reproducible.
+func Random(seed uint64) Strategy {
+	rnd := newRand(seed, 0)
+
+	return func(_ uint64, amount int) (int, bool) {
+		return rnd.IntN(amount), true
+	}
+}
+
+// Unique creates the Strategy that gives every record exactly once, so no two
+// tasks share the same record. The tasks after the last record fail with
+// ErrFeedExhausted, use WithTasks to stop the Worker in time.
+func Unique() Strategy {
+	return func(num uint64, amount int) (int, bool) {
+		if num >= uint64(amount) { //nolint:gosec // amount is positive
+			return 0, false
+		}
+
+		return int(num), true //nolint:gosec // num is less than amount
+	}
+}
+
+// FromSlice creates the Feeder that gives the records in order of the Strategy.
+func FromSlice[T any](records []T, strategy Strategy) Feeder[T] {
+	var (
+		num   uint64
+		mutex sync.Mutex
+	)
+
+	return func() (T, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		var zero T
+
+		if len(records) == 0 {
+			return zero, ErrFeedExhausted.Reason("no records")
+		}
+
+		idx, ok := strategy(num, len(records))
+		if !ok {
+			return zero, ErrFeedExhausted.Reason("all records are used")
+		}
+
+		num++
+
+		return records[idx], nil
+	}
+}
+
+// Generate creates the endless Feeder that makes the record for the task
+// number num (from 0) by the given function.
+func Generate[T any](generate func(num uint64) T) Feeder[T] {
+	var (
+		num   uint64
+		mutex sync.Mutex
+	)
+
+	return func() (T, error) {
+		mutex.Lock()
+		cur := num
+		num++
+		mutex.Unlock()
+
+		return generate(cur), nil
+	}
+}
+
+// ReadCSV reads the CSV records, e.g. from a file, for FromSlice. The first line
+// is the header, so every record maps the names of the columns to the values.
+func ReadCSV(reader io.Reader) ([]map[string]string, error) {
+	lines, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, ex.Conv(err)
+	}
+
+	if len(lines) == 0 {
+		return make([]map[string]string, 0), nil
+	}
+
+	header, rows := lines[0], lines[1:]
+	records := make([]map[string]string, 0, len(rows))
+
+	for _, row := range rows {
+		record := make(map[string]string, len(header))
+		for idx, name := range header {
+			record[name] = row[idx]
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// ReadJSONLines reads the JSON lines (one JSON value per line), e.g. from a file,
+// for FromSlice. Every line is decoded into a separate record.
+func ReadJSONLines[T any](reader io.Reader) ([]T, error) {
+	var (
+		records = make([]T, 0)
+		decoder = json.NewDecoder(reader)
+	)
+
+	for {
+		var record T
+
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+
+		if err != nil {
+			return nil, ex.Conv(err)
+		}
+
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,203 @@
+package pusher_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+func drain[T any](t *testing.T, feeder pusher.Feeder[T], amount int) []T {
+	t.Helper()
+
+	records := make([]T, 0, amount)
+
+	for range amount {
+		record, err := feeder()
+		require.NoError(t, err)
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestFromSliceSequential(t *testing.T) {
+	t.Parallel()
+
+	feeder := pusher.FromSlice([]string{"a", "b", "c"}, pusher.Sequential())
+
+	assert.Equal(t, []string{"a", "b", "c", "a", "b"}, drain(t, feeder, 5))
+}
+
+func TestFromSliceRandom(t *testing.T) {
+	t.Parallel()
+
+	records := []int{1, 2, 3, 4, 5}
+
+	first := drain(t, pusher.FromSlice(records, pusher.Random(42)), 20)
+	second := drain(t, pusher.FromSlice(records, pusher.Random(42)), 20)
+
+	assert.Equal(t, first, second)
+	assert.Subset(t, records, first)
+	assert.NotEqual(t, drain(t, pusher.FromSlice(records, pusher.Sequential()), 20), first)
+}
+
+func TestFromSliceUnique(t *testing.T) {
+	t.Parallel()
+
+	var (
+		records = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+		feeder  = pusher.FromSlice(records, pusher.Unique())
+		mutex   sync.Mutex
+		wait    sync.WaitGroup
+		got     = make([]int, 0)
+	)
+
+	for range 3 * len(records) {
+		wait.Go(func() {
+			record, err := feeder()
+			if err != nil {
+				assert.ErrorIs(t, err, pusher.ErrFeedExhausted)
+
+				return
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			got = append(got, record)
+		})
+	}
+
+	wait.Wait()
+
+	assert.ElementsMatch(t, records, got)
+}
+
+func TestFromSliceEmpty(t *testing.T) {
+	t.Parallel()
+
+	_, err := pusher.FromSlice([]int{}, pusher.Sequential())()
+
+	require.ErrorIs(t, err, pusher.ErrFeedExhausted)
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	feeder := pusher.Generate(func(num uint64) string {
+		return "user-" + strconv.FormatUint(num, 10)
+	})
+
+	assert.Equal(t, []string{"user-0", "user-1", "user-2"}, drain(t, feeder, 3))
+}
+
+func TestReadCSV(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err   error
+		name  string
+		input string
+		want  []map[string]string
+	}{
+		{
+			name:  "records",
+			input: "login,password\njudas,secret\nwayne,batman\n",
+			want: []map[string]string{
+				{"login": "judas", "password": "secret"},
+				{"login": "wayne", "password": "batman"},
+			},
+			err: nil,
+		},
+		{name: "header only", input: "login,password\n", want: []map[string]string{}, err: nil},
+		{name: "empty", input: "", want: []map[string]string{}, err: nil},
+		{name: "broken", input: "login,password\njudas\n", want: nil, err: assert.AnError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := pusher.ReadCSV(strings.NewReader(test.input))
+
+			if test.err != nil {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestReadJSONLines(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	file := filepath.Join(t.TempDir(), "users.jsonl")
+	lines := `{"name":"judas","age":33}` + "\n" + `{"name":"wayne","age":42}` + "\n"
+	require.NoError(t, os.WriteFile(file, []byte(lines), 0o600))
+
+	reader, err := os.Open(file)
+	require.NoError(t, err)
+
+	defer func() { _ = reader.Close() }()
+
+	got, err := pusher.ReadJSONLines[user](reader)
+
+	require.NoError(t, err)
+	assert.Equal(t, []user{{Name: "judas", Age: 33}, {Name: "wayne", Age: 42}}, got)
+
+	_, err = pusher.ReadJSONLines[user](strings.NewReader(`{"name":`))
+
+	require.Error(t, err)
+}
+
+func TestFeed(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mutex sync.Mutex
+		seen  = make([]string, 0)
+	)
+
+	target := pusher.Feed(
+		pusher.FromSlice([]string{"a", "b", "c"}, pusher.Unique()),
+		func(_ context.Context, record string) (pusher.Result, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			seen = append(seen, record)
+
+			return result(record), nil
+		},
+	)
+
+	report, err := pusher.Work(pusher.Steady(100), 0, target, pusher.WithTasks(5))
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, seen)
+	assert.Equal(t, uint64(2), report.Failed)
+}
+
+func TestFeedMissing(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, pusher.Feed[int](nil, func(context.Context, int) (pusher.Result, error) { return nil, nil }))
+	assert.Nil(t, pusher.Feed(pusher.Generate(func(num uint64) uint64 { return num }), nil))
+}
@@ -0,0 +1,132 @@
+package pusher
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	// State is the bag of values shared by the steps of a single Flow iteration,
+	// e.g. the token got by "login" and used by "logout". Every iteration starts
+	// with the empty one, the steps run one by one, so it needs no locks.
+	State map[string]any
+
+	// Step is a named part of the Flow.
+	Step struct {
+		// Run does the work of the Step, it may read the values stored
+		// by the previous steps and store the new ones.
+		Run func(ctx context.Context, state State) (Result, error)
+		// Name tags the AfterStep Gossip of the Step and its error.
+		Name string
+	}
+
+	// StepError is the error of the failed Step of the Flow. It unwraps to the error
+	// of the Step, so the error keeps its kind and the name of the Step is the context.
+	StepError struct {
+		Err  error
+		Step string
+	}
+
+	// herald keeps the AfterStep gossips of the task till the Target returns,
+	// so the slow listeners don't hold the steps back.
+	herald struct {
+		steps []*Gossip
+		task  Gossip
+		mutex sync.Mutex
+	}
+
+	// heraldKey is the context key of the herald.
+	heraldKey struct{}
+)
+
+// Flow creates the Target that runs the steps one after another, e.g.
+// login → list → get item → logout, passing them the State of the iteration.
+// The first failed Step stops the iteration, so the task fails with the StepError
+// of its error and name. Otherwise the Result is the one of the last Step.
+// Besides the task events every Step emits the AfterStep Gossip with its name,
+// timings, Result and error, so the listeners may break the results down by step.
+// The AfterStep gossips are sent when the Target returns, just before the AfterTarget one.
+func Flow(steps ...Step) Target {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	return func(ctx context.Context) (Result, error) {
+		var (
+			state  = make(State)
+			teller = tell(ctx)
+			result Result
+			err    error
+		)
+
+		for _, step := range steps {
+			start := time.Now()
+			result, err = step.Run(ctx, state)
+
+			teller.step(step.Name, start, result, err)
+
+			if err != nil {
+				return result, &StepError{Err: err, Step: step.Name}
+			}
+		}
+
+		return result, nil
+	}
+}
+
+// Error returns the error of the Step prefixed by its name, e.g. "login: forbidden".
+func (e *StepError) Error() string {
+	return e.Step + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error of the Step.
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// withHerald makes the steps of the task visible for the listeners.
+func withHerald(ctx context.Context, task Gossip) (context.Context, *herald) {
+	teller := &herald{steps: make([]*Gossip, 0), task: task, mutex: sync.Mutex{}}
+
+	return context.WithValue(ctx, heraldKey{}, teller), teller
+}
+
+// tell returns the herald of the task or nil if nobody listens.
+func tell(ctx context.Context) *herald {
+	teller, _ := ctx.Value(heraldKey{}).(*herald)
+
+	return teller
+}
+
+// step keeps the AfterStep Gossip of the finished Step.
+func (h *herald) step(name string, start time.Time, result Result, err error) {
+	if h == nil {
+		return
+	}
+
+	gossip := h.task
+	gossip.Result = result
+	gossip.Error = err
+	gossip.Start = start
+	gossip.End = time.Now()
+	gossip.When = AfterStep
+	gossip.Step = name
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.steps = append(h.steps, &gossip)
+}
+
+// told returns the AfterStep gossips of the finished steps.
+func (h *herald) told() []*Gossip {
+	if h == nil {
+		return nil
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.steps
+}
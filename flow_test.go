@@ -0,0 +1,199 @@
+package pusher_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+var errForbidden = errors.New("forbidden")
+
+func journey(calls *atomic.Int64, denied bool) []pusher.Step {
+	return []pusher.Step{
+		{
+			Name: "login",
+			Run: func(_ context.Context, state pusher.State) (pusher.Result, error) {
+				calls.Add(1)
+
+				state["token"] = "secret"
+
+				return result("logged in"), nil
+			},
+		},
+		{
+			Name: "list",
+			Run: func(_ context.Context, state pusher.State) (pusher.Result, error) {
+				calls.Add(1)
+
+				if denied {
+					return result("denied"), errForbidden
+				}
+
+				return result("listed with " + state["token"].(string)), nil //nolint:forcetypeassert // set by login
+			},
+		},
+		{
+			Name: "logout",
+			Run: func(_ context.Context, state pusher.State) (pusher.Result, error) {
+				calls.Add(1)
+
+				token, _ := state["token"].(string)
+				delete(state, "token")
+
+				return result("logged out " + token), nil
+			},
+		},
+	}
+}
+
+func TestFlowMissing(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, pusher.Flow())
+}
+
+func TestFlow(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err    error
+		result string
+		text   string
+		calls  int64
+	}
+
+	tests := []struct {
+		name   string
+		want   want
+		denied bool
+	}{
+		{
+			name:   "success",
+			denied: false,
+			want:   want{result: "logged out secret", err: nil, text: "", calls: 3},
+		},
+		{
+			name:   "failure",
+			denied: true,
+			want:   want{result: "denied", err: errForbidden, text: "list: forbidden", calls: 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int64
+
+			got, err := pusher.Flow(journey(&calls, test.denied)...)(t.Context())
+
+			require.ErrorIs(t, err, test.want.err)
+
+			if test.want.err != nil {
+				require.EqualError(t, err, test.want.text)
+
+				var failed *pusher.StepError
+
+				require.ErrorAs(t, err, &failed)
+				assert.Equal(t, "list", failed.Step)
+				assert.Equal(t, test.want.err, errors.Unwrap(err), "the error of the step is the primary one")
+			}
+
+			assert.Equal(t, test.want.result, got.String())
+			assert.Equal(t, test.want.calls, calls.Load())
+
+			// the state belongs to a single iteration
+			got, err = pusher.Flow(journey(&calls, false)...)(t.Context())
+
+			require.NoError(t, err)
+			assert.Equal(t, "logged out secret", got.String())
+		})
+	}
+}
+
+func TestFlowGossips(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls    atomic.Int64
+		tasks    = 5
+		listener = newRecorder()
+	)
+
+	_, run := runner(pusher.Flow(journey(&calls, false)...), pusher.WithGossips(listener), pusher.WithTasks(tasks))
+
+	require.NoError(t, run(t.Context(), pusher.Steady(1000)))
+
+	var (
+		steps  = make(map[string]int)
+		events = make(map[uint64][]pusher.When)
+	)
+
+	for _, gossip := range listener.Gossips() {
+		events[gossip.Seq] = append(events[gossip.Seq], gossip.When)
+
+		if gossip.AfterStep() {
+			steps[gossip.Step]++
+
+			require.NoError(t, gossip.Error)
+			assert.False(t, gossip.End.Before(gossip.Start))
+		}
+	}
+
+	assert.Equal(t, map[string]int{"login": tasks, "list": tasks, "logout": tasks}, steps)
+
+	want := []pusher.When{
+		pusher.BeforeTarget,
+		pusher.AfterStep,
+		pusher.AfterStep,
+		pusher.AfterStep,
+		pusher.AfterTarget,
+	}
+
+	for seq, whens := range events {
+		assert.Equal(t, want, whens, seq)
+	}
+}
+
+// lazy is the slow listener that remembers the latency of the last task.
+type lazy struct {
+	latency atomic.Int64
+}
+
+func (l *lazy) Listen(_ context.Context, _ *pusher.Worker, gossips <-chan *pusher.Gossip) {
+	for gossip := range gossips {
+		time.Sleep(50 * time.Millisecond)
+
+		if gossip.AfterTarget() {
+			l.latency.Store(int64(gossip.Latency()))
+		}
+	}
+}
+
+func (l *lazy) Stop() {}
+
+func TestFlowSlowListener(t *testing.T) {
+	t.Parallel()
+
+	var (
+		listener = new(lazy)
+		step     = pusher.Step{Name: "instant", Run: func(context.Context, pusher.State) (pusher.Result, error) {
+			return result("done"), nil
+		}}
+	)
+
+	_, err := pusher.Work(pusher.Steady(1), 0, pusher.Flow(step, step, step, step),
+		pusher.WithGossips(listener),
+		pusher.WithTasks(1),
+	)
+
+	require.NoError(t, err)
+	assert.Less(t, time.Duration(listener.latency.Load()), 25*time.Millisecond, "the listener doesn't hold the steps")
+}
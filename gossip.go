@@ -1,6 +1,9 @@
 package pusher
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 const (
 	// BeforeTarget is the moment just before the Target function is called.
@@ -9,6 +12,10 @@ const (
 	// AfterTarget is the moment just after the Target function returns.
 	AfterTarget When = "after-target"
 
+	// AfterStep is the moment just after a Step of the Flow returns,
+	// it comes between BeforeTarget and AfterTarget of the task.
+	AfterStep When = "after-step"
+
 	// Canceled indicates that a scheduled task was skipped because the concurrency
 	// limit was reached.
 	Canceled When = "canceled"
@@ -19,11 +26,28 @@ type (
 	When string
 
 	// Gossip represents a telemetry event generated during a Worker's operation.
-	// It contains the result, an error, and the task lifecycle stage.
+	// It contains the result, an error, the task lifecycle stage, its identity and timings.
 	Gossip struct {
 		Result Result
 		Error  error
-		When   When
+		// Tick is the moment the task was intended to start according to the schedule.
+		// It may be earlier than Start if the Worker is overloaded.
+		Tick time.Time
+		// Start is the moment the task was started, it's zero for Canceled events.
+		Start time.Time
+		// End is the moment the Target returned, it's set only for AfterTarget events.
+		// For AfterStep events Start and End are the moments of the Step.
+		End  time.Time
+		When When
+		// Scenario is the name of the Scenario the task belongs to (see WithMix),
+		// it's empty for the Worker without the mix.
+		Scenario string
+		// Step is the name of the Step of the Flow, it's set only for AfterStep events.
+		Step string
+		// Seq is the task number within a single Work call, starting from 1.
+		// All events of the same task share it, so BeforeTarget and AfterTarget
+		// can be paired with each other.
+		Seq uint64
 	}
 
 	// Gossiper defines the interface for listeners that process Gossip events.
@@ -33,6 +57,7 @@ type (
 		Listen(ctx context.Context, worker *Worker, gossips <-chan *Gossip)
 
 		// Stop is called to gracefully shut down the listener and flush any buffered data.
+		// The Worker calls it only after Listen has returned.
 		Stop()
 	}
 )
@@ -52,6 +77,33 @@ func (g *Gossip) AfterTarget() bool {
 	return g.When == AfterTarget
 }
 
+// AfterStep returns true if the Gossip event occurred after a Step of the Flow.
+func (g *Gossip) AfterStep() bool {
+	return g.When == AfterStep
+}
+
+// Latency returns the time spent in the Target call (or in the Step for
+// AfterStep) or zero if the Gossip event is neither AfterTarget nor AfterStep.
+func (g *Gossip) Latency() time.Duration {
+	if !g.AfterTarget() && !g.AfterStep() {
+		return 0
+	}
+
+	return g.End.Sub(g.Start)
+}
+
+// Response returns the time passed from the intended start of the task till
+// the Target returned or zero if the Gossip event isn't AfterTarget. Unlike
+// Latency, it includes the time the task was late, so it isn't affected
+// by the coordinated omission.
+func (g *Gossip) Response() time.Duration {
+	if !g.AfterTarget() {
+		return 0
+	}
+
+	return g.End.Sub(g.Tick)
+}
+
 func (g *Gossip) String() string {
 	if g == nil {
 		return "<nil>"
@@ -2,6 +2,7 @@ package pusher_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -16,19 +17,30 @@ func TestGossipStates(t *testing.T) {
 		when pusher.When
 		want []bool
 	}{
-		{name: "canceled", when: pusher.Canceled, want: []bool{true, false, false}},
-		{name: "before target", when: pusher.BeforeTarget, want: []bool{false, true, false}},
-		{name: "after target", when: pusher.AfterTarget, want: []bool{false, false, true}},
-		{name: "unsupported", when: pusher.When("unsupported"), want: []bool{false, false, false}},
+		{name: "canceled", when: pusher.Canceled, want: []bool{true, false, false, false}},
+		{name: "before target", when: pusher.BeforeTarget, want: []bool{false, true, false, false}},
+		{name: "after target", when: pusher.AfterTarget, want: []bool{false, false, true, false}},
+		{name: "after step", when: pusher.AfterStep, want: []bool{false, false, false, true}},
+		{name: "unsupported", when: pusher.When("unsupported"), want: []bool{false, false, false, false}},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
 
-			gossip := pusher.Gossip{When: test.when, Result: nil, Error: nil}
+			gossip := pusher.Gossip{
+				When:     test.when,
+				Result:   nil,
+				Error:    nil,
+				Tick:     time.Time{},
+				Start:    time.Time{},
+				End:      time.Time{},
+				Scenario: "",
+				Step:     "",
+				Seq:      0,
+			}
 
-			got := []bool{gossip.Canceled(), gossip.BeforeTarget(), gossip.AfterTarget()}
+			got := []bool{gossip.Canceled(), gossip.BeforeTarget(), gossip.AfterTarget(), gossip.AfterStep()}
 
 			assert.Equal(t, test.want, got)
 		})
@@ -44,11 +56,35 @@ func TestGossipString(t *testing.T) {
 		want   string
 	}{
 		{name: "nil", gossip: nil, want: "<nil>"},
-		{name: "empty", gossip: &pusher.Gossip{Result: nil, Error: nil, When: pusher.BeforeTarget}, want: "<empty>"},
 		{
-			name:   "smoke",
-			gossip: &pusher.Gossip{Result: result("useful"), Error: nil, When: pusher.AfterTarget},
-			want:   "useful",
+			name: "empty",
+			gossip: &pusher.Gossip{
+				Result:   nil,
+				Error:    nil,
+				When:     pusher.BeforeTarget,
+				Tick:     time.Time{},
+				Start:    time.Time{},
+				End:      time.Time{},
+				Scenario: "",
+				Step:     "",
+				Seq:      0,
+			},
+			want: "<empty>",
+		},
+		{
+			name: "smoke",
+			gossip: &pusher.Gossip{
+				Result:   result("useful"),
+				Error:    nil,
+				When:     pusher.AfterTarget,
+				Tick:     time.Time{},
+				Start:    time.Time{},
+				End:      time.Time{},
+				Scenario: "",
+				Step:     "",
+				Seq:      0,
+			},
+			want: "useful",
 		},
 	}
 
@@ -62,3 +98,88 @@ func TestGossipString(t *testing.T) {
 		})
 	}
 }
+
+func TestGossipLatency(t *testing.T) {
+	t.Parallel()
+
+	var (
+		start = time.Now()
+		end   = start.Add(time.Second)
+	)
+
+	tests := []struct {
+		name string
+		when pusher.When
+		want time.Duration
+	}{
+		{name: "canceled", when: pusher.Canceled, want: 0},
+		{name: "before target", when: pusher.BeforeTarget, want: 0},
+		{name: "after target", when: pusher.AfterTarget, want: time.Second},
+		{name: "after step", when: pusher.AfterStep, want: time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			gossip := pusher.Gossip{
+				When:     test.when,
+				Result:   nil,
+				Error:    nil,
+				Tick:     start,
+				Start:    start,
+				End:      end,
+				Scenario: "",
+				Step:     "",
+				Seq:      1,
+			}
+
+			got := gossip.Latency()
+
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestGossipResponse(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tick  = time.Now()
+		start = tick.Add(time.Second)
+		end   = start.Add(time.Second)
+	)
+
+	tests := []struct {
+		name string
+		when pusher.When
+		want time.Duration
+	}{
+		{name: "canceled", when: pusher.Canceled, want: 0},
+		{name: "before target", when: pusher.BeforeTarget, want: 0},
+		{name: "after target", when: pusher.AfterTarget, want: 2 * time.Second},
+		{name: "after step", when: pusher.AfterStep, want: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			gossip := pusher.Gossip{
+				When:     test.when,
+				Result:   nil,
+				Error:    nil,
+				Tick:     tick,
+				Start:    start,
+				End:      end,
+				Scenario: "",
+				Step:     "",
+				Seq:      1,
+			}
+
+			got := gossip.Response()
+
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Package grpcx provides the ready-made Targets that call the unary
+// and the server-streaming gRPC methods.
+package grpcx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/therenotomorrow/ex"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+// ErrUnexpectedCode is returned by the Target when the Policy rejects the status code.
+const ErrUnexpectedCode = ex.Error("unexpected code")
+
+type (
+	// Factory creates the request and the empty reply for a single call,
+	// e.g. func() (any, any) { return &pb.PingRequest{}, new(pb.PingReply) }.
+	// For the server-streaming call the reply is reused for every message.
+	Factory func() (request, reply any)
+
+	// Result is the pusher.Result of a single gRPC call.
+	Result struct {
+		// Message is the description of the status, it's empty for the OK one.
+		Message string
+		// Code is the status code of the call.
+		Code codes.Code
+		// Received is the amount of the reply messages, it's 1 for a successful unary call.
+		Received int
+	}
+
+	// Policy turns the status code of the call into an error, nil means success.
+	Policy func(code codes.Code) error
+
+	// Option is a functional option for configuring the Target.
+	Option func(b *builder)
+
+	// builder holds the configuration of the Target.
+	builder struct {
+		conn    grpc.ClientConnInterface
+		factory Factory
+		policy  Policy
+		method  string
+		options []grpc.CallOption
+	}
+)
+
+func (r Result) String() string {
+	if r.Message == "" {
+		return fmt.Sprintf("%s, %d received", r.Code, r.Received)
+	}
+
+	return fmt.Sprintf("%s: %s, %d received", r.Code, r.Message, r.Received)
+}
+
+// Success creates the Policy that accepts only the OK status code, the default one.
+func Success() Policy {
+	return Expect(codes.OK)
+}
+
+// Expect creates the Policy that accepts only the given status codes,
+// e.g. Expect(codes.OK, codes.NotFound).
+func Expect(accepted ...codes.Code) Policy {
+	return func(code codes.Code) error {
+		if slices.Contains(accepted, code) {
+			return nil
+		}
+
+		return ErrUnexpectedCode.Reason(code.String())
+	}
+}
+
+// WithPolicy sets the Policy that decides which status codes are failures.
+// The default one is Success.
+func WithPolicy(policy Policy) Option {
+	return func(b *builder) {
+		if policy == nil {
+			policy = Success()
+		}
+
+		b.policy = policy
+	}
+}
+
+// WithCallOptions sets the options of every call, e.g. grpc.WaitForReady(true).
+func WithCallOptions(options ...grpc.CallOption) Option {
+	return func(b *builder) {
+		b.options = options
+	}
+}
+
+// Unary creates the Target that invokes the unary method, given by its full
+// name like "/package.Service/Method", with the messages from the factory.
+// It returns nil for the nil connection or factory, so the Worker
+// reports pusher.ErrMissingTarget.
+func Unary(conn grpc.ClientConnInterface, method string, factory Factory, options ...Option) pusher.Target {
+	build := newBuilder(conn, method, factory, options)
+	if build == nil {
+		return nil
+	}
+
+	return func(ctx context.Context) (pusher.Result, error) {
+		request, reply := build.factory()
+
+		err := build.conn.Invoke(ctx, build.method, request, reply, build.options...)
+
+		received := 0
+		if err == nil {
+			received = 1
+		}
+
+		return build.verdict(err, received)
+	}
+}
+
+// Stream creates the Target that calls the server-streaming method, given
+// by its full name like "/package.Service/Method", and receives all the reply
+// messages till the end of the stream. It returns nil for the nil connection
+// or factory, so the Worker reports pusher.ErrMissingTarget.
+func Stream(conn grpc.ClientConnInterface, method string, factory Factory, options ...Option) pusher.Target {
+	build := newBuilder(conn, method, factory, options)
+	if build == nil {
+		return nil
+	}
+
+	desc := &grpc.StreamDesc{StreamName: method, Handler: nil, ServerStreams: true, ClientStreams: false}
+
+	return func(ctx context.Context) (pusher.Result, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel() // releases the stream if it's left in the middle
+
+		request, reply := build.factory()
+
+		stream, err := build.conn.NewStream(ctx, desc, build.method, build.options...)
+		if err != nil {
+			return build.verdict(err, 0)
+		}
+
+		err = stream.SendMsg(request)
+		if err == nil {
+			err = stream.CloseSend()
+		}
+
+		// the server has ended the stream, its status comes with the RecvMsg
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+
+		received := 0
+
+		for err == nil {
+			err = stream.RecvMsg(reply)
+			if err == nil {
+				received++
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+
+		return build.verdict(err, received)
+	}
+}
+
+// newBuilder applies the options, it returns nil if the Target can't be built.
+func newBuilder(conn grpc.ClientConnInterface, method string, factory Factory, options []Option) *builder {
+	if conn == nil || factory == nil {
+		return nil
+	}
+
+	build := &builder{
+		conn:    conn,
+		factory: factory,
+		policy:  Success(),
+		method:  method,
+		options: make([]grpc.CallOption, 0),
+	}
+
+	for _, option := range options {
+		option(build)
+	}
+
+	return build
+}
+
+// verdict turns the error of the call into the Result and the error given by the Policy.
+func (b *builder) verdict(err error, received int) (Result, error) {
+	state := status.Convert(err)
+	result := Result{Message: state.Message(), Code: state.Code(), Received: received}
+
+	return result, b.policy(state.Code())
+}
@@ -0,0 +1,304 @@
+package grpcx_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/grpcx"
+)
+
+const (
+	check = "/grpc.health.v1.Health/Check"
+	watch = "/grpc.health.v1.Health/Watch"
+	// updates is the amount of messages sent by the Watch method.
+	updates = 3
+)
+
+// health answers by the name of the service: "ok" is serving, "slow" waits
+// for the end of the call, "missing" is not found and anything else is unavailable.
+type health struct {
+	healthpb.UnimplementedHealthServer
+}
+
+func (h *health) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	switch req.GetService() {
+	case "ok":
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+	case "slow":
+		<-ctx.Done()
+
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case "missing":
+		return nil, status.Error(codes.NotFound, "no such service")
+	default:
+		return nil, status.Error(codes.Unavailable, "service is down")
+	}
+}
+
+func (h *health) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	if req.GetService() != "ok" {
+		return status.Error(codes.Unavailable, "service is down")
+	}
+
+	for range updates {
+		err := stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dial starts the in-process server and connects to it.
+func dial(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	var (
+		listener = bufconn.Listen(1024 * 1024)
+		server   = grpc.NewServer()
+	)
+
+	healthpb.RegisterHealthServer(server, new(health))
+
+	go func() { _ = server.Serve(listener) }()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+
+		server.Stop()
+	})
+
+	return conn
+}
+
+// ended is the connection whose streams are ended by the server before the request is sent.
+type ended struct {
+	grpc.ClientConnInterface
+}
+
+func (e ended) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return rejected{ClientStream: nil}, nil
+}
+
+// rejected is the stream ended by the server with the PermissionDenied status.
+type rejected struct {
+	grpc.ClientStream
+}
+
+func (r rejected) SendMsg(any) error {
+	return io.EOF
+}
+
+func (r rejected) RecvMsg(any) error {
+	return status.Error(codes.PermissionDenied, "access denied")
+}
+
+func factory(service string) grpcx.Factory {
+	return func() (any, any) {
+		return &healthpb.HealthCheckRequest{Service: service}, new(healthpb.HealthCheckResponse)
+	}
+}
+
+func TestMissing(t *testing.T) {
+	t.Parallel()
+
+	conn := dial(t)
+
+	assert.Nil(t, grpcx.Unary(nil, check, factory("ok")))
+	assert.Nil(t, grpcx.Unary(conn, check, nil))
+	assert.Nil(t, grpcx.Stream(nil, watch, factory("ok")))
+	assert.Nil(t, grpcx.Stream(conn, watch, nil))
+}
+
+func TestUnary(t *testing.T) {
+	t.Parallel()
+
+	conn := dial(t)
+
+	type want struct {
+		err    error
+		result grpcx.Result
+	}
+
+	tests := []struct {
+		policy  grpcx.Policy
+		name    string
+		service string
+		want    want
+	}{
+		{
+			name:    "ok",
+			service: "ok",
+			policy:  nil,
+			want:    want{err: nil, result: grpcx.Result{Message: "", Code: codes.OK, Received: 1}},
+		},
+		{
+			name:    "unavailable",
+			service: "down",
+			policy:  nil,
+			want: want{
+				err:    grpcx.ErrUnexpectedCode,
+				result: grpcx.Result{Message: "service is down", Code: codes.Unavailable, Received: 0},
+			},
+		},
+		{
+			name:    "expected not found",
+			service: "missing",
+			policy:  grpcx.Expect(codes.OK, codes.NotFound),
+			want: want{
+				err:    nil,
+				result: grpcx.Result{Message: "no such service", Code: codes.NotFound, Received: 0},
+			},
+		},
+		{
+			name:    "unexpected ok",
+			service: "ok",
+			policy:  grpcx.Expect(codes.NotFound),
+			want:    want{err: grpcx.ErrUnexpectedCode, result: grpcx.Result{Message: "", Code: codes.OK, Received: 1}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			target := grpcx.Unary(conn, check, factory(test.service), grpcx.WithPolicy(test.policy))
+
+			got, err := target(t.Context())
+
+			require.ErrorIs(t, err, test.want.err)
+			assert.Equal(t, test.want.result, got)
+		})
+	}
+}
+
+func TestUnaryDeadline(t *testing.T) {
+	t.Parallel()
+
+	var (
+		conn   = dial(t)
+		target = grpcx.Unary(conn, check, factory("slow"), grpcx.WithCallOptions(grpc.WaitForReady(true)))
+	)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	got, err := target(ctx)
+
+	require.ErrorIs(t, err, grpcx.ErrUnexpectedCode)
+
+	result, ok := got.(grpcx.Result)
+
+	require.True(t, ok)
+	assert.Equal(t, codes.DeadlineExceeded, result.Code)
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	conn := dial(t)
+
+	tests := []struct {
+		err     error
+		name    string
+		service string
+		want    grpcx.Result
+	}{
+		{
+			name:    "ok",
+			service: "ok",
+			err:     nil,
+			want:    grpcx.Result{Message: "", Code: codes.OK, Received: updates},
+		},
+		{
+			name:    "unavailable",
+			service: "down",
+			err:     grpcx.ErrUnexpectedCode,
+			want:    grpcx.Result{Message: "service is down", Code: codes.Unavailable, Received: 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			target := grpcx.Stream(conn, watch, factory(test.service))
+
+			got, err := target(t.Context())
+
+			require.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestStreamEnded(t *testing.T) {
+	t.Parallel()
+
+	target := grpcx.Stream(ended{ClientConnInterface: nil}, watch, factory("ok"))
+
+	got, err := target(t.Context())
+
+	require.ErrorIs(t, err, grpcx.ErrUnexpectedCode)
+	assert.Equal(t, grpcx.Result{Message: "access denied", Code: codes.PermissionDenied, Received: 0}, got)
+}
+
+func TestResultString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		want   string
+		result grpcx.Result
+	}{
+		{name: "ok", result: grpcx.Result{Message: "", Code: codes.OK, Received: 3}, want: "OK, 3 received"},
+		{
+			name:   "failure",
+			result: grpcx.Result{Message: "service is down", Code: codes.Unavailable, Received: 0},
+			want:   "Unavailable: service is down, 0 received",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.want, test.result.String())
+		})
+	}
+}
+
+func TestUnaryWork(t *testing.T) {
+	t.Parallel()
+
+	target := grpcx.Unary(dial(t), check, factory("ok"))
+
+	report, err := pusher.Work(pusher.Steady(100), 0, target, pusher.WithTasks(20))
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), report.Completed)
+	assert.Zero(t, report.Failed)
+}
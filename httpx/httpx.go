@@ -0,0 +1,274 @@
+// Package httpx provides the ready-made Target that sends HTTP requests
+// and measures the timings of every call.
+package httpx
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/therenotomorrow/ex"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+const (
+	// ErrUnexpectedStatus is returned by the Target when the Policy rejects the status code.
+	ErrUnexpectedStatus = ex.Error("unexpected status")
+
+	// ErrStaticBody is returned by the Target when the body of the template can't be sent twice.
+	ErrStaticBody = ex.Error("body is not replayable")
+)
+
+// idle is the amount of kept-alive connections to a host: the load usually goes
+// to a single one, so the default of the http.Transport is too small.
+const idle = 1024
+
+type (
+	// Timings is the breakdown of a single HTTP call. The phases that weren't
+	// needed (e.g. DNS and Connect for a reused connection) are zero.
+	Timings struct {
+		// DNS is the time spent to resolve the host.
+		DNS time.Duration
+		// Connect is the time spent to establish the TCP connection.
+		Connect time.Duration
+		// TLS is the time spent for the TLS handshake.
+		TLS time.Duration
+		// TTFB (time to first byte) is the time from the start of the call
+		// till the first byte of the response.
+		TTFB time.Duration
+		// Total is the time from the start of the call till the whole body is read.
+		Total time.Duration
+	}
+
+	// Result is the pusher.Result of a single HTTP call.
+	Result struct {
+		Timings Timings
+		// Bytes is the amount of the response body bytes read.
+		Bytes int64
+		// Status is the status code of the response.
+		Status int
+		// Reused tells whether the call used a kept-alive connection.
+		Reused bool
+	}
+
+	// Policy turns the status code of the response into an error, nil means success.
+	Policy func(status int) error
+
+	// Option is a functional option for configuring the Target.
+	Option func(b *builder)
+
+	// builder holds the configuration of the Target.
+	builder struct {
+		client  *http.Client
+		policy  Policy
+		timeout time.Duration
+	}
+
+	// trace collects the moments of a single call reported by httptrace.
+	trace struct {
+		dnsStart     time.Time
+		dnsDone      time.Time
+		connectStart time.Time
+		connectDone  time.Time
+		tlsStart     time.Time
+		tlsDone      time.Time
+		firstByte    time.Time
+		mutex        sync.Mutex
+		reused       bool
+	}
+)
+
+func (r Result) String() string {
+	return fmt.Sprintf("%d %s, %d bytes in %s", r.Status, http.StatusText(r.Status), r.Bytes, r.Timings.Total)
+}
+
+// Success creates the Policy that accepts the 1xx, 2xx and 3xx status codes, the default one.
+func Success() Policy {
+	return func(status int) error {
+		if status < http.StatusBadRequest {
+			return nil
+		}
+
+		return ErrUnexpectedStatus.Reason(strconv.Itoa(status))
+	}
+}
+
+// Expect creates the Policy that accepts only the given status codes.
+func Expect(statuses ...int) Policy {
+	return func(status int) error {
+		if slices.Contains(statuses, status) {
+			return nil
+		}
+
+		return ErrUnexpectedStatus.Reason(strconv.Itoa(status))
+	}
+}
+
+// WithClient sets the client used for all the calls. By default the Target
+// creates its own client with the connection pool tuned for the load.
+func WithClient(client *http.Client) Option {
+	return func(b *builder) {
+		b.client = client
+	}
+}
+
+// WithTimeout limits the time of a single call including the reading
+// of the response body. Zero means no limit, the default.
+func WithTimeout(timeout time.Duration) Option {
+	return func(b *builder) {
+		b.timeout = timeout
+	}
+}
+
+// WithPolicy sets the Policy that decides which status codes are failures.
+// The default one is Success.
+func WithPolicy(policy Policy) Option {
+	return func(b *builder) {
+		if policy == nil {
+			policy = Success()
+		}
+
+		b.policy = policy
+	}
+}
+
+// New creates the Target that sends a copy of the template request on every call.
+// The body of the template must be replayable, that's true for the requests
+// created by http.NewRequest with bytes.Reader, bytes.Buffer or strings.Reader.
+// It returns nil for the nil template, so the Worker reports pusher.ErrMissingTarget.
+func New(template *http.Request, options ...Option) pusher.Target {
+	if template == nil {
+		return nil
+	}
+
+	build := &builder{client: nil, policy: Success(), timeout: 0}
+
+	for _, option := range options {
+		option(build)
+	}
+
+	if build.client == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert // it's always the Transport
+		transport.MaxIdleConnsPerHost = idle
+
+		build.client = &http.Client{Transport: transport}
+	}
+
+	return func(ctx context.Context) (pusher.Result, error) {
+		return build.call(ctx, template)
+	}
+}
+
+// call sends a copy of the template and reads the whole response.
+func (b *builder) call(ctx context.Context, template *http.Request) (Result, error) {
+	var (
+		tracer = new(trace)
+		result = Result{
+			Timings: Timings{DNS: 0, Connect: 0, TLS: 0, TTFB: 0, Total: 0},
+			Bytes:   0,
+			Status:  0,
+			Reused:  false,
+		}
+		start = time.Now()
+	)
+
+	if b.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, b.timeout)
+		defer cancel()
+	}
+
+	req := template.Clone(httptrace.WithClientTrace(ctx, tracer.hooks()))
+
+	if template.Body != nil && template.Body != http.NoBody {
+		if template.GetBody == nil {
+			return result, ErrStaticBody.Reason("use http.NewRequest with the bytes or strings reader")
+		}
+
+		body, err := template.GetBody()
+		if err != nil {
+			return result, ex.Conv(err)
+		}
+
+		req.Body = body
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return result, ex.Conv(err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	result.Status = resp.StatusCode
+	result.Bytes, err = io.Copy(io.Discard, resp.Body)
+	result.Timings, result.Reused = tracer.timings(start, time.Now())
+
+	if err != nil {
+		return result, ex.Conv(err)
+	}
+
+	return result, b.policy(resp.StatusCode)
+}
+
+// hooks creates the httptrace.ClientTrace that fills the trace.
+func (t *trace) hooks() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { t.mark(&t.dnsDone) },
+		ConnectStart:         func(string, string) { t.mark(&t.connectStart) },
+		ConnectDone:          func(string, string, error) { t.mark(&t.connectDone) },
+		TLSHandshakeStart:    func() { t.mark(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.mark(&t.tlsDone) },
+		GotFirstResponseByte: func() { t.mark(&t.firstByte) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mutex.Lock()
+			defer t.mutex.Unlock()
+
+			t.reused = info.Reused
+		},
+	}
+}
+
+// mark sets the moment if it isn't set yet: the first attempt to connect
+// is the one that counts, even if several of them race each other.
+func (t *trace) mark(moment *time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if moment.IsZero() {
+		*moment = time.Now()
+	}
+}
+
+// timings turns the collected moments into the Timings of the call that lasted from start till end.
+func (t *trace) timings(start, end time.Time) (Timings, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return Timings{
+		DNS:     span(t.dnsStart, t.dnsDone),
+		Connect: span(t.connectStart, t.connectDone),
+		TLS:     span(t.tlsStart, t.tlsDone),
+		TTFB:    span(start, t.firstByte),
+		Total:   end.Sub(start),
+	}, t.reused
+}
+
+// span returns the time between the moments or zero if any of them is missing.
+func span(from, till time.Time) time.Duration {
+	if from.IsZero() || till.IsZero() {
+		return 0
+	}
+
+	return till.Sub(from)
+}
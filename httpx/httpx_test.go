@@ -0,0 +1,223 @@
+package httpx_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/httpx"
+)
+
+// echo responds with the method and the body of the request,
+// the status code may be given by the "status" query parameter.
+func echo() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+
+		if status := request.URL.Query().Get("status"); status != "" {
+			code, _ := strconv.Atoi(status)
+			writer.WriteHeader(code)
+		}
+
+		_, _ = writer.Write([]byte(request.Method + " " + string(body)))
+	})
+}
+
+func template(t *testing.T, method, url string, body io.Reader) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(t.Context(), method, url, body)
+	require.NoError(t, err)
+
+	return req
+}
+
+func TestNewMissing(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, httpx.New(nil))
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(echo())
+	defer server.Close()
+
+	target := httpx.New(template(t, http.MethodPost, server.URL, strings.NewReader("ping")))
+
+	for call := range 2 {
+		got, err := target(t.Context())
+
+		require.NoError(t, err)
+
+		result, ok := got.(httpx.Result)
+
+		require.True(t, ok)
+		assert.Equal(t, http.StatusOK, result.Status)
+		assert.Equal(t, int64(len("POST ping")), result.Bytes)
+		assert.Equal(t, call > 0, result.Reused)
+		assert.Positive(t, result.Timings.TTFB)
+		assert.GreaterOrEqual(t, result.Timings.Total, result.Timings.TTFB)
+		assert.Zero(t, result.Timings.TLS)
+
+		if result.Reused {
+			assert.Zero(t, result.Timings.Connect)
+		} else {
+			assert.Positive(t, result.Timings.Connect)
+		}
+	}
+}
+
+func TestNewTLS(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(echo())
+	defer server.Close()
+
+	target := httpx.New(template(t, http.MethodGet, server.URL, nil), httpx.WithClient(server.Client()))
+
+	got, err := target(t.Context())
+
+	require.NoError(t, err)
+
+	result, ok := got.(httpx.Result)
+
+	require.True(t, ok)
+	assert.Positive(t, result.Timings.TLS)
+	assert.Equal(t, "200 OK, 4 bytes in "+result.Timings.Total.String(), result.String())
+}
+
+func TestNewDNS(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(echo())
+	defer server.Close()
+
+	url := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	target := httpx.New(template(t, http.MethodGet, url, nil))
+
+	got, err := target(t.Context())
+
+	require.NoError(t, err)
+
+	result, ok := got.(httpx.Result)
+
+	require.True(t, ok)
+	assert.Positive(t, result.Timings.DNS)
+}
+
+func TestNewPolicy(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(echo())
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		err    error
+		policy httpx.Policy
+		name   string
+		status int
+	}{
+		{name: "default ok", policy: nil, status: http.StatusOK, err: nil},
+		{name: "default redirect", policy: nil, status: http.StatusNotModified, err: nil},
+		{name: "default failure", policy: nil, status: http.StatusBadGateway, err: httpx.ErrUnexpectedStatus},
+		{name: "success failure", policy: httpx.Success(), status: http.StatusNotFound, err: httpx.ErrUnexpectedStatus},
+		{name: "expect ok", policy: httpx.Expect(http.StatusNotFound), status: http.StatusNotFound, err: nil},
+		{
+			name:   "expect failure",
+			policy: httpx.Expect(http.StatusCreated),
+			status: http.StatusOK,
+			err:    httpx.ErrUnexpectedStatus,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			url := server.URL + "?status=" + strconv.Itoa(test.status)
+			target := httpx.New(template(t, http.MethodGet, url, nil), httpx.WithPolicy(test.policy))
+
+			got, err := target(t.Context())
+
+			require.ErrorIs(t, err, test.err)
+
+			result, ok := got.(httpx.Result)
+
+			require.True(t, ok)
+			assert.Equal(t, test.status, result.Status)
+		})
+	}
+}
+
+func TestNewStaticBody(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(echo())
+	defer server.Close()
+
+	req := template(t, http.MethodPost, server.URL, io.NopCloser(strings.NewReader("once")))
+	target := httpx.New(req)
+
+	_, err := target(t.Context())
+
+	require.ErrorIs(t, err, httpx.ErrStaticBody)
+}
+
+func TestNewCanceled(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, request *http.Request) {
+		<-request.Context().Done()
+	}))
+	defer server.Close()
+
+	target := httpx.New(template(t, http.MethodGet, server.URL, nil))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := target(ctx)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNewTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, request *http.Request) {
+		<-request.Context().Done()
+	}))
+	defer server.Close()
+
+	target := httpx.New(template(t, http.MethodGet, server.URL, nil), httpx.WithTimeout(50*time.Millisecond))
+
+	_, err := target(t.Context())
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNewWork(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(echo())
+	defer server.Close()
+
+	target := httpx.New(template(t, http.MethodPut, server.URL, strings.NewReader("item")))
+
+	report, err := pusher.Work(pusher.Steady(100), 0, target, pusher.WithTasks(20))
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), report.Completed)
+	assert.Zero(t, report.Failed)
+}
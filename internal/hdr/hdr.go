@@ -0,0 +1,232 @@
+// Package hdr implements a compact log-linear histogram of durations
+// in the spirit of HdrHistogram.
+//
+// Values below 128ns are stored exactly, larger values are stored in buckets
+// whose width grows with the magnitude of the value, so the relative error
+// of any reported value stays below 1/64 (~1.6%) over the whole int64 range.
+// All methods are safe for concurrent use.
+package hdr
+
+import (
+	"math"
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// precision is the number of bits used to address sub-buckets.
+	precision = 7
+	subCount  = 1 << precision
+	halfCount = subCount / 2
+	// size is the amount of buckets needed to cover all non-negative int64 values.
+	size = subCount + (63-precision)*halfCount
+	// half is used to find the middle of a bucket.
+	half = 2
+)
+
+// Histogram records durations and answers quantile queries.
+type Histogram struct {
+	counts []atomic.Uint64
+	total  atomic.Uint64
+	sum    atomic.Int64
+	least  atomic.Int64
+	most   atomic.Int64
+}
+
+// New creates an empty Histogram.
+func New() *Histogram {
+	hist := &Histogram{
+		counts: make([]atomic.Uint64, size),
+		total:  atomic.Uint64{},
+		sum:    atomic.Int64{},
+		least:  atomic.Int64{},
+		most:   atomic.Int64{},
+	}
+
+	hist.least.Store(math.MaxInt64)
+
+	return hist
+}
+
+// Record adds the value to the histogram, negative values are recorded as zero.
+func (h *Histogram) Record(value time.Duration) {
+	h.RecordN(value, 1)
+}
+
+// RecordN adds the value to the histogram count times.
+func (h *Histogram) RecordN(value time.Duration, count uint64) {
+	if count == 0 {
+		return
+	}
+
+	value = max(value, 0)
+
+	h.counts[index(uint64(value))].Add(count)
+	h.total.Add(count)
+	h.sum.Add(int64(value) * int64(count)) //nolint:gosec // count is reasonably small
+
+	lower(&h.least, int64(value))
+	raise(&h.most, int64(value))
+}
+
+// RecordCorrected adds the value like Record and compensates the coordinated
+// omission like HdrHistogram does: if the value is larger than the expected
+// interval between values, it also adds the values that would have been seen
+// by the tasks that were not issued while waiting for this one.
+func (h *Histogram) RecordCorrected(value, interval time.Duration) {
+	h.Record(value)
+
+	if interval <= 0 {
+		return
+	}
+
+	for missing := value - interval; missing >= interval; missing -= interval {
+		h.Record(missing)
+	}
+}
+
+// Merge adds all values recorded by the other histogram.
+func (h *Histogram) Merge(other *Histogram) {
+	for idx := range other.counts {
+		count := other.counts[idx].Load()
+		if count == 0 {
+			continue
+		}
+
+		h.counts[idx].Add(count)
+	}
+
+	h.total.Add(other.total.Load())
+	h.sum.Add(other.sum.Load())
+
+	lower(&h.least, other.least.Load())
+	raise(&h.most, other.most.Load())
+}
+
+// Count returns the amount of recorded values.
+func (h *Histogram) Count() uint64 {
+	return h.total.Load()
+}
+
+// Min returns the smallest recorded value or zero if the histogram is empty.
+func (h *Histogram) Min() time.Duration {
+	if h.Count() == 0 {
+		return 0
+	}
+
+	return time.Duration(h.least.Load())
+}
+
+// Max returns the largest recorded value or zero if the histogram is empty.
+func (h *Histogram) Max() time.Duration {
+	return time.Duration(h.most.Load())
+}
+
+// Mean returns the exact arithmetic mean of the recorded values.
+func (h *Histogram) Mean() time.Duration {
+	count := h.Count()
+	if count == 0 {
+		return 0
+	}
+
+	return time.Duration(h.sum.Load() / int64(count)) //nolint:gosec // count is reasonably small
+}
+
+// StdDev returns the standard deviation of the recorded values
+// computed from the bucket midpoints.
+func (h *Histogram) StdDev() time.Duration {
+	count := h.Count()
+	if count == 0 {
+		return 0
+	}
+
+	var (
+		mean   = float64(h.Mean())
+		square float64
+	)
+
+	for idx := range h.counts {
+		hits := h.counts[idx].Load()
+		if hits == 0 {
+			continue
+		}
+
+		low, high := bounds(idx)
+		dev := float64(low+high)/half - mean
+		square += dev * dev * float64(hits)
+	}
+
+	return time.Duration(math.Sqrt(square / float64(count)))
+}
+
+// Quantile returns the value below which the given fraction (0..1) of
+// the recorded values falls. Like HdrHistogram, it reports the highest
+// value equivalent to the found bucket, capped by Max.
+func (h *Histogram) Quantile(fraction float64) time.Duration {
+	count := h.Count()
+	if count == 0 {
+		return 0
+	}
+
+	fraction = min(max(fraction, 0), 1)
+	rank := max(uint64(math.Ceil(fraction*float64(count))), 1)
+
+	var seen uint64
+
+	for idx := range h.counts {
+		seen += h.counts[idx].Load()
+		if seen < rank {
+			continue
+		}
+
+		_, high := bounds(idx)
+
+		return min(time.Duration(high), h.Max()) //nolint:gosec // high is a valid duration
+	}
+
+	return h.Max()
+}
+
+// lower atomically replaces the stored value if the given one is smaller.
+func lower(stored *atomic.Int64, value int64) {
+	for old := stored.Load(); value < old; old = stored.Load() {
+		if stored.CompareAndSwap(old, value) {
+			return
+		}
+	}
+}
+
+// raise atomically replaces the stored value if the given one is larger.
+func raise(stored *atomic.Int64, value int64) {
+	for old := stored.Load(); value > old; old = stored.Load() {
+		if stored.CompareAndSwap(old, value) {
+			return
+		}
+	}
+}
+
+// index returns the bucket for the value.
+func index(value uint64) int {
+	if value < subCount {
+		return int(value)
+	}
+
+	shift := bits.Len64(value) - precision
+
+	return subCount + (shift-1)*halfCount + int(value>>shift) - halfCount //nolint:gosec // value>>shift < subCount
+}
+
+// bounds returns the lowest and the highest values stored in the bucket.
+func bounds(idx int) (uint64, uint64) {
+	if idx < subCount {
+		return uint64(idx), uint64(idx)
+	}
+
+	var (
+		shift = (idx-subCount)/halfCount + 1
+		sub   = uint64((idx-subCount)%halfCount + halfCount) //nolint:gosec // always positive
+	)
+
+	return sub << shift, (sub+1)<<shift - 1
+}
@@ -0,0 +1,188 @@
+package hdr_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/therenotomorrow/pusher/internal/hdr"
+)
+
+// tolerance is the maximal relative error of the histogram.
+const tolerance = 1.0 / 64
+
+func TestHistogramEmpty(t *testing.T) {
+	t.Parallel()
+
+	hist := hdr.New()
+
+	assert.Zero(t, hist.Count())
+	assert.Zero(t, hist.Min())
+	assert.Zero(t, hist.Max())
+	assert.Zero(t, hist.Mean())
+	assert.Zero(t, hist.StdDev())
+	assert.Zero(t, hist.Quantile(0.5))
+}
+
+func TestHistogramExact(t *testing.T) {
+	t.Parallel()
+
+	hist := hdr.New()
+
+	for value := range 100 {
+		hist.Record(time.Duration(value + 1))
+	}
+
+	assert.Equal(t, uint64(100), hist.Count())
+	assert.Equal(t, time.Duration(1), hist.Min())
+	assert.Equal(t, time.Duration(100), hist.Max())
+	assert.Equal(t, time.Duration(50), hist.Mean())
+	assert.Equal(t, time.Duration(50), hist.Quantile(0.5))
+	assert.Equal(t, time.Duration(99), hist.Quantile(0.99))
+	assert.Equal(t, time.Duration(100), hist.Quantile(1))
+	assert.Equal(t, time.Duration(1), hist.Quantile(0))
+	assert.InDelta(t, 28.87, float64(hist.StdDev()), 1)
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	t.Parallel()
+
+	hist := hdr.New()
+
+	for value := range 10_000 {
+		hist.Record(time.Duration(value+1) * time.Microsecond)
+	}
+
+	tests := []struct {
+		name     string
+		fraction float64
+		want     time.Duration
+	}{
+		{name: "p50", fraction: 0.5, want: 5 * time.Millisecond},
+		{name: "p90", fraction: 0.9, want: 9 * time.Millisecond},
+		{name: "p99", fraction: 0.99, want: 9900 * time.Microsecond},
+		{name: "p99.9", fraction: 0.999, want: 9990 * time.Microsecond},
+		{name: "max", fraction: 1, want: 10 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := hist.Quantile(test.fraction)
+
+			assert.InEpsilon(t, float64(test.want), float64(got), tolerance)
+		})
+	}
+}
+
+func TestHistogramNegative(t *testing.T) {
+	t.Parallel()
+
+	hist := hdr.New()
+
+	hist.Record(-time.Second)
+
+	assert.Equal(t, uint64(1), hist.Count())
+	assert.Zero(t, hist.Max())
+	assert.Zero(t, hist.Min())
+}
+
+func TestHistogramHuge(t *testing.T) {
+	t.Parallel()
+
+	hist := hdr.New()
+
+	hist.Record(math.MaxInt64)
+
+	assert.Equal(t, time.Duration(math.MaxInt64), hist.Quantile(0.5))
+}
+
+func TestHistogramRecordN(t *testing.T) {
+	t.Parallel()
+
+	hist := hdr.New()
+
+	hist.RecordN(time.Second, 0)
+	hist.RecordN(time.Second, 3)
+
+	assert.Equal(t, uint64(3), hist.Count())
+	assert.Equal(t, time.Second, hist.Mean())
+}
+
+func TestHistogramRecordCorrected(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		value    time.Duration
+		interval time.Duration
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want uint64
+	}{
+		{name: "fast", args: args{value: time.Millisecond, interval: 10 * time.Millisecond}, want: 1},
+		{name: "slow", args: args{value: 45 * time.Millisecond, interval: 10 * time.Millisecond}, want: 4},
+		{name: "disabled", args: args{value: time.Second, interval: 0}, want: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			hist := hdr.New()
+
+			hist.RecordCorrected(test.args.value, test.args.interval)
+
+			assert.Equal(t, test.want, hist.Count())
+			assert.Equal(t, test.args.value, hist.Max())
+		})
+	}
+}
+
+func TestHistogramMerge(t *testing.T) {
+	t.Parallel()
+
+	var (
+		one   = hdr.New()
+		two   = hdr.New()
+		empty = hdr.New()
+	)
+
+	one.Record(time.Millisecond)
+	two.Record(time.Second)
+
+	one.Merge(two)
+	one.Merge(empty)
+
+	assert.Equal(t, uint64(2), one.Count())
+	assert.Equal(t, time.Millisecond, one.Min())
+	assert.Equal(t, time.Second, one.Max())
+}
+
+func TestHistogramConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		hist = hdr.New()
+		wait = sync.WaitGroup{}
+	)
+
+	for range 10 {
+		wait.Go(func() {
+			for value := range 1000 {
+				hist.Record(time.Duration(value))
+			}
+		})
+	}
+
+	wait.Wait()
+
+	assert.Equal(t, uint64(10_000), hist.Count())
+	assert.Equal(t, time.Duration(999), hist.Max())
+}
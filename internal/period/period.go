@@ -0,0 +1,29 @@
+// Package period tracks the listening time of the run for the listeners:
+// when the run begins and ends by the moments of its events.
+package period
+
+import "time"
+
+// Span is the listening time of the run, from the earliest marked moment
+// to the latest one. It's not safe for concurrent use, the owner guards
+// it with its own mutex.
+type Span struct {
+	Begin time.Time
+	End   time.Time
+}
+
+// Mark extends the span with the given moment.
+func (s *Span) Mark(moment time.Time) {
+	if s.Begin.IsZero() || moment.Before(s.Begin) {
+		s.Begin = moment
+	}
+
+	if moment.After(s.End) {
+		s.End = moment
+	}
+}
+
+// Duration returns the time between the earliest and the latest moments.
+func (s *Span) Duration() time.Duration {
+	return s.End.Sub(s.Begin)
+}
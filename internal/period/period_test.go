@@ -0,0 +1,30 @@
+package period_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/therenotomorrow/pusher/internal/period"
+)
+
+func TestSpan(t *testing.T) {
+	t.Parallel()
+
+	var (
+		span  period.Span
+		begin = time.Now()
+	)
+
+	assert.Zero(t, span.Duration())
+
+	span.Mark(begin.Add(time.Second))
+	span.Mark(begin)
+	span.Mark(begin.Add(3 * time.Second))
+	span.Mark(begin.Add(2 * time.Second))
+
+	assert.Equal(t, begin, span.Begin)
+	assert.Equal(t, begin.Add(3*time.Second), span.End)
+	assert.Equal(t, 3*time.Second, span.Duration())
+}
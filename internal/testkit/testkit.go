@@ -0,0 +1,64 @@
+// Package testkit holds the fixtures shared by the tests of the subpackages:
+// the Result, the failing Target, the Gossip factory and the broken writer.
+package testkit
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/therenotomorrow/ex"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+// ErrOops is the error of the failed tasks and writes.
+const ErrOops = ex.Error("oops")
+
+type (
+	// Result is the pusher.Result of the fixtures.
+	Result string
+
+	// Broken is the writer that fails every write with ErrOops.
+	Broken struct{}
+)
+
+func (r Result) String() string {
+	return string(r)
+}
+
+func (Broken) Write([]byte) (int, error) {
+	return 0, ErrOops
+}
+
+// Flaky creates the Target that fails every even task. The errors differ
+// by the number of the task, but all of them wrap ErrOops.
+func Flaky() pusher.Target {
+	var calls atomic.Int64
+
+	return func(_ context.Context) (pusher.Result, error) {
+		call := calls.Add(1)
+		if call%2 == 1 {
+			return Result("done"), nil
+		}
+
+		return nil, fmt.Errorf("task #%d: %w", call, ErrOops)
+	}
+}
+
+// Gossip creates the event of the scenario that ends at the given moment
+// and lasts for the latency, the task started right at its tick.
+func Gossip(when pusher.When, scenario string, end time.Time, latency time.Duration, err error) *pusher.Gossip {
+	return &pusher.Gossip{
+		Result:   Result("done"),
+		Error:    err,
+		Tick:     end.Add(-latency),
+		Start:    end.Add(-latency),
+		End:      end,
+		When:     when,
+		Scenario: scenario,
+		Step:     "",
+		Seq:      1,
+	}
+}
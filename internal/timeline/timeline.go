@@ -0,0 +1,139 @@
+// Package timeline splits the run into the windows of a fixed width. Only the
+// latest windows stay open for the late events, the older ones keep only their
+// Summary, so the timeline of a long run takes a little memory.
+package timeline
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/therenotomorrow/pusher/internal/hdr"
+)
+
+const (
+	// settled is the amount of the latest windows that are still open for the late events.
+	settled = 2
+	p50     = 0.5
+	p90     = 0.9
+	p95     = 0.95
+	p99     = 0.99
+)
+
+type (
+	// Timeline is the run split into the windows. It's not safe for concurrent
+	// use, the owner guards it with its own mutex.
+	Timeline struct {
+		open     map[int64]*Window
+		closed   []Summary
+		interval time.Duration
+		// floor is the index of the oldest window that may still be open.
+		floor int64
+	}
+
+	// Window is a single open interval of the timeline.
+	Window struct {
+		// Hist holds the latencies of the completed tasks, the failed ones too.
+		Hist *hdr.Histogram
+		// Offset is the start of the window from the beginning of the run.
+		Offset   time.Duration
+		Failed   int64
+		Canceled int64
+	}
+
+	// Summary is what is left of the window when it's closed.
+	Summary struct {
+		// Offset is the start of the window from the beginning of the run.
+		Offset time.Duration
+		Min    time.Duration
+		Mean   time.Duration
+		P50    time.Duration
+		P90    time.Duration
+		P95    time.Duration
+		P99    time.Duration
+		Max    time.Duration
+		// Completed counts the tasks finished in the window, the failed ones too.
+		Completed int64
+		Failed    int64
+		Canceled  int64
+	}
+)
+
+// New creates an empty Timeline of the windows of the given width.
+func New(interval time.Duration) *Timeline {
+	return &Timeline{
+		open:     make(map[int64]*Window),
+		closed:   make([]Summary, 0),
+		interval: interval,
+		floor:    0,
+	}
+}
+
+// Window returns the open window of the given offset from the beginning of the run.
+// The windows older than the settled ones are closed, the late event of the
+// closed window goes to the oldest open one.
+func (t *Timeline) Window(offset time.Duration) *Window {
+	idx := max(int64(offset/t.interval), t.floor)
+
+	for old, win := range t.open {
+		if old <= idx-settled {
+			t.closed = append(t.closed, win.summarize())
+			t.floor = max(t.floor, old+1)
+
+			delete(t.open, old)
+		}
+	}
+
+	win, ok := t.open[idx]
+	if !ok {
+		win = &Window{Hist: hdr.New(), Offset: time.Duration(idx) * t.interval, Failed: 0, Canceled: 0}
+		t.open[idx] = win
+	}
+
+	return win
+}
+
+// Windows returns the summaries of all the windows ordered by the offset, the gaps
+// between them are filled with the empty summaries, so the timeline is continuous.
+func (t *Timeline) Windows() []Summary {
+	known := slices.Clone(t.closed)
+
+	for _, win := range t.open {
+		known = append(known, win.summarize())
+	}
+
+	slices.SortFunc(known, func(a, b Summary) int { return cmp.Compare(a.Offset, b.Offset) })
+
+	windows := make([]Summary, 0, len(known))
+
+	for _, summary := range known {
+		if len(windows) > 0 {
+			next := windows[len(windows)-1].Offset + t.interval
+
+			for offset := next; offset < summary.Offset; offset += t.interval {
+				windows = append(windows, Summary{Offset: offset}) //nolint:exhaustruct // the window is empty
+			}
+		}
+
+		windows = append(windows, summary)
+	}
+
+	return windows
+}
+
+// summarize takes the Summary of the window.
+func (w *Window) summarize() Summary {
+	return Summary{
+		Offset:    w.Offset,
+		Min:       w.Hist.Min(),
+		Mean:      w.Hist.Mean(),
+		P50:       w.Hist.Quantile(p50),
+		P90:       w.Hist.Quantile(p90),
+		P95:       w.Hist.Quantile(p95),
+		P99:       w.Hist.Quantile(p99),
+		Max:       w.Hist.Max(),
+		Completed: int64(w.Hist.Count()), //nolint:gosec // the amount of tasks is reasonably small
+		Failed:    w.Failed,
+		Canceled:  w.Canceled,
+	}
+}
@@ -0,0 +1,114 @@
+package timeline_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher/internal/timeline"
+)
+
+func offsets(windows []timeline.Summary) []time.Duration {
+	list := make([]time.Duration, 0, len(windows))
+
+	for _, win := range windows {
+		list = append(list, win.Offset)
+	}
+
+	return list
+}
+
+func TestTimelineEmpty(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, timeline.New(time.Second).Windows())
+}
+
+func TestTimelineWindow(t *testing.T) {
+	t.Parallel()
+
+	line := timeline.New(time.Second)
+
+	first := line.Window(100 * time.Millisecond)
+	first.Failed++
+
+	assert.Same(t, first, line.Window(900*time.Millisecond))
+	assert.Equal(t, time.Duration(0), first.Offset)
+
+	second := line.Window(1500 * time.Millisecond)
+
+	assert.Equal(t, time.Second, second.Offset)
+	assert.Same(t, first, line.Window(0), "the window is open")
+
+	// the first window is closed, its late events go to the oldest open one
+	line.Window(2 * time.Second)
+
+	assert.Same(t, second, line.Window(0))
+	assert.Same(t, second, line.Window(-time.Second))
+}
+
+func TestTimelineWindows(t *testing.T) {
+	t.Parallel()
+
+	line := timeline.New(time.Second)
+
+	line.Window(0).Canceled++
+	line.Window(3 * time.Second).Hist.Record(time.Millisecond)
+	line.Window(10*time.Second).Failed++
+	line.Window(11 * time.Second)
+
+	windows := line.Windows()
+
+	require.Len(t, windows, 12)
+	assert.Equal(t, time.Duration(0), windows[0].Offset)
+	assert.Equal(t, 11*time.Second, windows[11].Offset)
+
+	for idx, offset := range offsets(windows) {
+		assert.Equal(t, time.Duration(idx)*time.Second, offset)
+	}
+
+	assert.Equal(t, int64(1), windows[0].Canceled)
+	assert.Equal(t, int64(1), windows[3].Completed)
+	assert.Equal(t, time.Millisecond, windows[3].P99)
+	assert.Equal(t, int64(1), windows[10].Failed)
+	assert.Zero(t, windows[5].Completed)
+	assert.Zero(t, windows[5].Max)
+}
+
+func TestTimelineSummary(t *testing.T) {
+	t.Parallel()
+
+	line := timeline.New(time.Second)
+	first := line.Window(0)
+
+	for value := range 100 {
+		first.Hist.Record(time.Duration(value+1) * time.Millisecond)
+	}
+
+	first.Failed = 3
+
+	// the first window is closed and keeps only its summary
+	line.Window(5 * time.Second)
+	first.Hist.Record(time.Hour)
+
+	windows := line.Windows()
+
+	require.Len(t, windows, 6)
+	assert.Equal(t, timeline.Summary{
+		Offset:    0,
+		Min:       time.Millisecond,
+		Mean:      50500 * time.Microsecond,
+		P50:       windows[0].P50,
+		P90:       windows[0].P90,
+		P95:       windows[0].P95,
+		P99:       windows[0].P99,
+		Max:       100 * time.Millisecond,
+		Completed: 100,
+		Failed:    3,
+		Canceled:  0,
+	}, windows[0])
+	assert.InDelta(t, 50*time.Millisecond, windows[0].P50, float64(time.Millisecond))
+	assert.InDelta(t, 95*time.Millisecond, windows[0].P95, float64(2*time.Millisecond))
+}
@@ -0,0 +1,154 @@
+// Package journal provides the Gossiper that writes every Gossip as a line
+// of JSON and the Replay of such a log into any Gossiper, e.g. to recompute
+// the statistics of the run offline.
+package journal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/therenotomorrow/ex"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+type (
+	// Record is a single line of the log.
+	Record struct {
+		Tick  time.Time `json:"tick,omitzero"`
+		Start time.Time `json:"start,omitzero"`
+		End   time.Time `json:"end,omitzero"`
+		// Worker is the Worker.String of the Worker the Gossip came from.
+		Worker   string      `json:"worker"`
+		When     pusher.When `json:"when"`
+		Scenario string      `json:"scenario,omitempty"`
+		Step     string      `json:"step,omitempty"`
+		// Error is the text of the error, it's empty for the successful tasks.
+		Error string `json:"error,omitempty"`
+		// Result is the Result.String, it's empty for the nil Result.
+		Result string `json:"result,omitempty"`
+		Seq    uint64 `json:"seq"`
+	}
+
+	// Result is the Result of the replayed Gossip: only its text is kept.
+	Result string
+
+	// Writer is a Gossiper that writes every Gossip to the underlying writer
+	// as a line of JSON. The writes are buffered and flushed by Stop. It's safe
+	// to share one Writer between several workers, e.g. with pusher.Farm.
+	Writer struct {
+		err    error
+		buffer *bufio.Writer
+		mutex  sync.Mutex
+	}
+)
+
+func (r Result) String() string {
+	return string(r)
+}
+
+// NewRecord turns the Gossip of the named Worker into the Record.
+func NewRecord(worker string, gossip *pusher.Gossip) Record {
+	record := Record{
+		Tick:     gossip.Tick,
+		Start:    gossip.Start,
+		End:      gossip.End,
+		Worker:   worker,
+		When:     gossip.When,
+		Scenario: gossip.Scenario,
+		Step:     gossip.Step,
+		Error:    "",
+		Result:   "",
+		Seq:      gossip.Seq,
+	}
+
+	if gossip.Error != nil {
+		record.Error = gossip.Error.Error()
+	}
+
+	if gossip.Result != nil {
+		record.Result = gossip.Result.String()
+	}
+
+	return record
+}
+
+// Gossip turns the Record back into the Gossip. The error keeps only its text,
+// the Result is the Result type or nil if the text is empty.
+func (r Record) Gossip() *pusher.Gossip {
+	gossip := &pusher.Gossip{
+		Result:   nil,
+		Error:    nil,
+		Tick:     r.Tick,
+		Start:    r.Start,
+		End:      r.End,
+		When:     r.When,
+		Scenario: r.Scenario,
+		Step:     r.Step,
+		Seq:      r.Seq,
+	}
+
+	if r.Error != "" {
+		gossip.Error = ex.Error(r.Error)
+	}
+
+	if r.Result != "" {
+		gossip.Result = Result(r.Result)
+	}
+
+	return gossip
+}
+
+// New creates the Writer to the given writer, e.g. the opened file.
+func New(writer io.Writer) *Writer {
+	return &Writer{err: nil, buffer: bufio.NewWriter(writer), mutex: sync.Mutex{}}
+}
+
+// Listen writes the gossips until the channel is closed.
+func (w *Writer) Listen(_ context.Context, worker *pusher.Worker, gossips <-chan *pusher.Gossip) {
+	ident := worker.String()
+
+	for gossip := range gossips {
+		line, err := json.Marshal(NewRecord(ident, gossip))
+
+		w.write(line, err)
+	}
+}
+
+// Stop flushes the buffered lines.
+func (w *Writer) Stop() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.err == nil {
+		w.err = ex.Conv(w.buffer.Flush())
+	}
+}
+
+// Err returns the first error of the writing, the lines after it are lost.
+func (w *Writer) Err() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.err
+}
+
+// write appends the line to the buffer unless the Writer is failed already.
+func (w *Writer) write(line []byte, err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.err != nil {
+		return
+	}
+
+	if err == nil {
+		_, err = w.buffer.Write(append(line, '\n'))
+	}
+
+	w.err = ex.Conv(err)
+}
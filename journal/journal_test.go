@@ -0,0 +1,263 @@
+package journal_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/internal/testkit"
+	"github.com/therenotomorrow/pusher/journal"
+	"github.com/therenotomorrow/pusher/stats"
+)
+
+// idle doesn't listen at all.
+type idle struct{}
+
+func (idle) Listen(context.Context, *pusher.Worker, <-chan *pusher.Gossip) {}
+
+func (idle) Stop() {}
+
+// tally counts the gossips by the worker and the stage.
+type tally struct {
+	counts map[string]int
+	mutex  sync.Mutex
+}
+
+func (t *tally) Listen(_ context.Context, worker *pusher.Worker, gossips <-chan *pusher.Gossip) {
+	for gossip := range gossips {
+		t.mutex.Lock()
+		t.counts[worker.String()+" "+string(gossip.When)]++
+		t.mutex.Unlock()
+	}
+}
+
+func (t *tally) Stop() {}
+
+func records(t *testing.T, log string) []journal.Record {
+	t.Helper()
+
+	var (
+		scanner = bufio.NewScanner(strings.NewReader(log))
+		lines   = make([]journal.Record, 0)
+	)
+
+	for scanner.Scan() {
+		var record journal.Record
+
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+
+		lines = append(lines, record)
+	}
+
+	return lines
+}
+
+func canceled(worker string, seq uint64) journal.Record {
+	return journal.Record{
+		Tick:     time.Time{},
+		Start:    time.Time{},
+		End:      time.Time{},
+		Worker:   worker,
+		When:     pusher.Canceled,
+		Scenario: "",
+		Step:     "",
+		Error:    "",
+		Result:   "",
+		Seq:      seq,
+	}
+}
+
+func marshal(t *testing.T, record journal.Record) string {
+	t.Helper()
+
+	line, err := json.Marshal(record)
+	require.NoError(t, err)
+
+	return string(line)
+}
+
+func TestWriter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		log    bytes.Buffer
+		writer = journal.New(&log)
+	)
+
+	_, err := pusher.Work(pusher.Steady(1000), 0, testkit.Flaky(), pusher.WithGossips(writer), pusher.WithTasks(4))
+
+	require.NoError(t, err)
+	require.NoError(t, writer.Err())
+
+	lines := records(t, log.String())
+
+	require.Len(t, lines, 8)
+
+	var results, failures int
+
+	for _, line := range lines {
+		assert.Equal(t, "judas", line.Worker)
+		assert.Positive(t, line.Seq)
+		assert.False(t, line.Tick.IsZero())
+		assert.False(t, line.Start.IsZero())
+
+		if line.When != pusher.AfterTarget {
+			assert.Equal(t, pusher.BeforeTarget, line.When)
+			assert.True(t, line.End.IsZero())
+
+			continue
+		}
+
+		assert.False(t, line.End.IsZero())
+
+		switch {
+		case line.Result == "done":
+			results++
+		case strings.HasSuffix(line.Error, "oops"):
+			failures++
+		}
+	}
+
+	assert.Equal(t, 2, results)
+	assert.Equal(t, 2, failures)
+}
+
+func TestWriterFailure(t *testing.T) {
+	t.Parallel()
+
+	writer := journal.New(testkit.Broken{})
+
+	_, err := pusher.Work(pusher.Steady(1000), 0, testkit.Flaky(), pusher.WithGossips(writer), pusher.WithTasks(2))
+
+	require.NoError(t, err)
+	require.ErrorIs(t, writer.Err(), testkit.ErrOops)
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tick   = time.Now().UTC()
+		gossip = &pusher.Gossip{
+			Result:   testkit.Result("done"),
+			Error:    testkit.ErrOops,
+			Tick:     tick,
+			Start:    tick.Add(time.Millisecond),
+			End:      tick.Add(time.Second),
+			When:     pusher.AfterStep,
+			Scenario: "browse",
+			Step:     "login",
+			Seq:      42,
+		}
+	)
+
+	line, err := json.Marshal(journal.NewRecord("somebody", gossip))
+
+	require.NoError(t, err)
+
+	var record journal.Record
+
+	require.NoError(t, json.Unmarshal(line, &record))
+
+	got := record.Gossip()
+
+	assert.Equal(t, "somebody", record.Worker)
+	assert.Equal(t, "done", got.String())
+	require.EqualError(t, got.Error, "oops")
+	assert.True(t, tick.Equal(got.Tick))
+	assert.Equal(t, time.Second-time.Millisecond, got.Latency())
+	assert.Equal(t, pusher.AfterStep, got.When)
+	assert.Equal(t, "browse", got.Scenario)
+	assert.Equal(t, "login", got.Step)
+	assert.Equal(t, uint64(42), got.Seq)
+
+	empty := canceled("somebody", 1).Gossip()
+
+	assert.Nil(t, empty.Result)
+	assert.NoError(t, empty.Error)
+	assert.JSONEq(t, `{"worker":"somebody","when":"canceled","seq":1}`, marshal(t, journal.NewRecord("somebody", empty)))
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	var (
+		log     bytes.Buffer
+		writer  = journal.New(&log)
+		workers = []*pusher.Worker{
+			pusher.Hire("first", testkit.Flaky(), pusher.WithGossips(writer), pusher.WithTasks(10)),
+			pusher.Hire("second", testkit.Flaky(), pusher.WithGossips(writer), pusher.WithTasks(6)),
+		}
+	)
+
+	report, err := pusher.Farm(pusher.Steady(1000), 0, workers)
+
+	require.NoError(t, err)
+	require.NoError(t, writer.Err())
+
+	var (
+		collector = stats.New()
+		counter   = &tally{counts: make(map[string]int), mutex: sync.Mutex{}}
+	)
+
+	require.NoError(t, journal.Replay(t.Context(), &log, collector, counter))
+
+	summary := collector.Summary()
+
+	assert.EqualValues(t, report.Completed, summary.Completed)
+	assert.EqualValues(t, report.Failed, summary.Failed)
+	assert.Equal(t, map[string]int{
+		"first before-target":  10,
+		"first after-target":   10,
+		"second before-target": 6,
+		"second after-target":  6,
+	}, counter.counts)
+}
+
+func TestReplayBroken(t *testing.T) {
+	t.Parallel()
+
+	var (
+		log     = `{"worker":"judas","when":"canceled","seq":1}` + "\n" + `{"worker":`
+		counter = &tally{counts: make(map[string]int), mutex: sync.Mutex{}}
+	)
+
+	err := journal.Replay(t.Context(), strings.NewReader(log), counter)
+
+	require.Error(t, err)
+	assert.Equal(t, map[string]int{"judas canceled": 1}, counter.counts)
+}
+
+func TestReplayCanceled(t *testing.T) {
+	t.Parallel()
+
+	var (
+		log   strings.Builder
+		cause = errors.New("enough")
+	)
+
+	for seq := range uint64(2048) {
+		log.WriteString(marshal(t, canceled("judas", seq)) + "\n")
+	}
+
+	ctx, cancel := context.WithCancelCause(t.Context())
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel(cause)
+	}()
+
+	err := journal.Replay(ctx, strings.NewReader(log.String()), idle{})
+
+	require.ErrorIs(t, err, cause)
+}
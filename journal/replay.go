@@ -0,0 +1,104 @@
+package journal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/therenotomorrow/ex"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+// backlog is the size of the channel of every listener, so the reading
+// doesn't wait for the slow listener after every line.
+const backlog = 1024
+
+// crowd is the listeners of the replayed worker.
+type crowd struct {
+	tracks []chan *pusher.Gossip
+}
+
+// Replay reads the log written by the Writer and passes every Gossip to the given
+// listeners as if they listen to the original workers: every Worker of the log
+// is recreated by its ident and the listeners listen to it till the end
+// of the log, then they are stopped. The timings of the gossips are the original
+// ones, but the listeners that use the wall-clock time (e.g. the duration
+// of the stats.Collector) see the time of the replay. It returns the first
+// error of the reading or the context, the gossips read before it are replayed anyway.
+func Replay(ctx context.Context, reader io.Reader, listeners ...pusher.Gossiper) error {
+	var (
+		decoder = json.NewDecoder(reader)
+		crowds  = make(map[string]*crowd)
+		chat    sync.WaitGroup
+		err     error
+	)
+
+	for err == nil {
+		var record Record
+
+		err = decoder.Decode(&record)
+		if err != nil {
+			break
+		}
+
+		group, ok := crowds[record.Worker]
+		if !ok {
+			group = gather(ctx, &chat, pusher.Hire(record.Worker, nil), listeners)
+			crowds[record.Worker] = group
+		}
+
+		err = group.tell(ctx, record.Gossip())
+	}
+
+	for _, group := range crowds {
+		for _, track := range group.tracks {
+			close(track)
+		}
+	}
+
+	chat.Wait()
+
+	for range crowds {
+		for _, listener := range listeners {
+			listener.Stop()
+		}
+	}
+
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	return ex.Conv(err)
+}
+
+// tell passes the gossip to every listener unless the context is done.
+func (c *crowd) tell(ctx context.Context, gossip *pusher.Gossip) error {
+	for _, track := range c.tracks {
+		select {
+		case track <- gossip:
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+
+	return nil
+}
+
+// gather starts the listeners of the worker.
+func gather(ctx context.Context, chat *sync.WaitGroup, worker *pusher.Worker, listeners []pusher.Gossiper) *crowd {
+	group := &crowd{tracks: make([]chan *pusher.Gossip, 0, len(listeners))}
+
+	for _, listener := range listeners {
+		track := make(chan *pusher.Gossip, backlog)
+		group.tracks = append(group.tracks, track)
+
+		chat.Go(func() {
+			listener.Listen(ctx, worker, track)
+		})
+	}
+
+	return group
+}
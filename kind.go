@@ -0,0 +1,20 @@
+package pusher
+
+import "errors"
+
+// Kind names the kind of the error for the breakdowns of the errors by the listeners,
+// e.g. promx and report. It must give a few distinct values, e.g. no request identifiers.
+type Kind func(err error) string
+
+// RootKind names the error by the innermost error of its chain, e.g. "unexpected status"
+// for the httpx.ErrUnexpectedStatus with any status, the default Kind of the listeners.
+func RootKind(err error) string {
+	for {
+		inner := errors.Unwrap(err)
+		if inner == nil {
+			return err.Error()
+		}
+
+		err = inner
+	}
+}
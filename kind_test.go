@@ -0,0 +1,44 @@
+package pusher_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/therenotomorrow/ex"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+func TestRootKind(t *testing.T) {
+	t.Parallel()
+
+	const errOops = ex.Error("oops")
+
+	tests := []struct {
+		err  error
+		name string
+		want string
+	}{
+		{name: "plain", err: errors.New("plain"), want: "plain"},
+		{name: "reason", err: errOops.Reason("even"), want: "oops"},
+		{name: "because", err: ex.Error("login").Because(errOops), want: "login"},
+		{name: "step", err: &pusher.StepError{Err: errOops.Reason("even"), Step: "login"}, want: "oops"},
+		{
+			name: "joined",
+			err:  fmt.Errorf("request #1: %w", fmt.Errorf("%w: %w", errOops, context.Canceled)),
+			want: "oops: context canceled",
+		},
+		{name: "deadline", err: ex.Conv(context.DeadlineExceeded), want: "context deadline exceeded"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.want, pusher.RootKind(test.err))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package pusher
+
+import (
+	"math/rand/v2"
+	"strconv"
+)
+
+type (
+	// Scenario is the named Target within the mix of the Worker, see WithMix.
+	Scenario struct {
+		Target Target
+		// Name tags every Gossip of the Scenario, so the listeners may tell
+		// the scenarios apart, e.g. to report the stats per endpoint.
+		Name string
+		// Weight is the share of the Scenario in the load relative to the others,
+		// e.g. 70, 25 and 5 for 70%, 25% and 5%. It must be positive.
+		Weight int
+	}
+
+	// mix picks the scenarios at random in proportion to their weights.
+	mix struct {
+		scenarios []Scenario
+		// bounds are the cumulative weights: the scenario i is picked
+		// for the numbers in [bounds[i-1], bounds[i]).
+		bounds []int
+	}
+)
+
+// newMix builds the mix of the Worker: the one given by WithMix or the single
+// unnamed Scenario of the Target given to Hire.
+func (w *Worker) newMix() *mix {
+	scenarios := w.config.scenarios
+	if len(scenarios) == 0 {
+		scenarios = []Scenario{{Target: w.target, Name: "", Weight: 1}}
+	}
+
+	var (
+		bounds = make([]int, 0, len(scenarios))
+		total  = 0
+	)
+
+	for _, scenario := range scenarios {
+		total += scenario.Weight
+		bounds = append(bounds, total)
+	}
+
+	return &mix{scenarios: scenarios, bounds: bounds}
+}
+
+// pick chooses the Scenario of the next task. The single one is picked without
+// the random source, so it doesn't affect the reproducibility of the Arrival.
+func (m *mix) pick(rnd *rand.Rand) *Scenario {
+	if len(m.scenarios) == 1 {
+		return &m.scenarios[0]
+	}
+
+	num := rnd.IntN(m.bounds[len(m.bounds)-1])
+
+	idx := 0
+	for num >= m.bounds[idx] {
+		idx++
+	}
+
+	return &m.scenarios[idx]
+}
+
+// validateMix checks that every Scenario may be picked and called.
+func validateMix(scenarios []Scenario) error {
+	for idx, scenario := range scenarios {
+		name := label(scenario.Name, idx)
+
+		if scenario.Target == nil {
+			return ErrInvalidMix.Reason(name + ": target is missing")
+		}
+
+		if scenario.Weight < 1 {
+			return ErrInvalidMix.Reason(name + ": weight must be positive")
+		}
+	}
+
+	return nil
+}
+
+// label names the Scenario in the errors, the unnamed one is named by its position.
+func label(name string, idx int) string {
+	if name != "" {
+		return strconv.Quote(name)
+	}
+
+	return "#" + strconv.Itoa(idx)
+}
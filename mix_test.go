@@ -0,0 +1,132 @@
+package pusher_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+func TestWorkerMixValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		text      string
+		scenarios []pusher.Scenario
+	}{
+		{
+			name:      "target",
+			scenarios: []pusher.Scenario{{Target: noop(), Name: "browse", Weight: 1}, {Target: nil, Name: "", Weight: 1}},
+			text:      "invalid mix: #1: target is missing",
+		},
+		{
+			name:      "zero",
+			scenarios: []pusher.Scenario{{Target: noop(), Name: "browse", Weight: 0}},
+			text:      `invalid mix: "browse": weight must be positive`,
+		},
+		{
+			name:      "negative",
+			scenarios: []pusher.Scenario{{Target: noop(), Name: "search", Weight: -1}},
+			text:      `invalid mix: "search": weight must be positive`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			worker := pusher.Hire("", nil, pusher.WithMix(test.scenarios...))
+
+			_, err := worker.Work(t.Context(), pusher.Steady(100))
+
+			require.ErrorIs(t, err, pusher.ErrInvalidMix)
+			require.EqualError(t, err, test.text)
+
+			_, err = worker.Crew(t.Context(), 1)
+
+			require.EqualError(t, err, test.text)
+			assert.False(t, worker.Config().Busy)
+		})
+	}
+}
+
+func TestWorkerMixShares(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tasks    = 1000
+		listener = newRecorder()
+		scenario = func(name string, weight int) pusher.Scenario {
+			return pusher.Scenario{Target: noop(), Name: name, Weight: weight}
+		}
+		worker = pusher.Hire("", nil,
+			pusher.WithMix(scenario("browse", 70), scenario("search", 25), scenario("checkout", 5)),
+			pusher.WithGossips(listener),
+			pusher.WithTasks(tasks),
+			pusher.WithSeed(42),
+		)
+	)
+
+	report, err := worker.Work(t.Context(), pusher.Steady(10_000))
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(tasks), report.Completed)
+
+	shares := make(map[string]int)
+
+	for _, gossip := range listener.Gossips() {
+		if gossip.AfterTarget() {
+			shares[gossip.Scenario]++
+		}
+	}
+
+	assert.InDelta(t, 700, shares["browse"], 50)
+	assert.InDelta(t, 250, shares["search"], 50)
+	assert.InDelta(t, 50, shares["checkout"], 25)
+}
+
+func TestWorkerMixTargets(t *testing.T) {
+	t.Parallel()
+
+	var (
+		listener = newRecorder()
+		worker   = pusher.Hire("", broken(),
+			pusher.WithMix(
+				pusher.Scenario{Target: noop(), Name: "ok", Weight: 1},
+				pusher.Scenario{Target: broken(), Name: "oops", Weight: 1},
+			),
+			pusher.WithGossips(listener),
+			pusher.WithIterations(50),
+		)
+	)
+
+	_, err := worker.Crew(t.Context(), 2)
+
+	require.NoError(t, err)
+
+	gossips := listener.Gossips()
+
+	require.Len(t, gossips, 200)
+
+	for _, gossip := range gossips {
+		if gossip.AfterTarget() {
+			assert.Equal(t, gossip.Scenario == "oops", gossip.Error != nil, gossip.Scenario)
+		}
+	}
+}
+
+func TestWorkerMixDefault(t *testing.T) {
+	t.Parallel()
+
+	listener := newRecorder()
+	_, run := runner(noop(), pusher.WithGossips(listener), pusher.WithTasks(10))
+
+	require.NoError(t, run(t.Context(), pusher.Steady(1000)))
+
+	for _, gossip := range listener.Gossips() {
+		assert.Empty(t, gossip.Scenario)
+	}
+}
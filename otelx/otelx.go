@@ -0,0 +1,165 @@
+// Package otelx provides the Interceptor that traces every task of the Worker
+// with OpenTelemetry and publishes the metrics of the tasks.
+package otelx
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+const (
+	// scope is the name of the instrumentation scope of the spans and the metrics.
+	scope           = "github.com/therenotomorrow/pusher/otelx"
+	defaultSpanName = "pusher.task"
+)
+
+// The attributes of the spans and the metrics.
+const (
+	WorkerKey   = attribute.Key("pusher.worker")
+	ScenarioKey = attribute.Key("pusher.scenario")
+	SeqKey      = attribute.Key("pusher.seq")
+	OutcomeKey  = attribute.Key("pusher.outcome")
+)
+
+type (
+	// Option is a functional option for configuring the Interceptor.
+	Option func(b *builder)
+
+	// builder holds the configuration of the Interceptor.
+	builder struct {
+		tracers trace.TracerProvider
+		meters  metric.MeterProvider
+		name    string
+	}
+
+	// instruments are the span and the metrics of the tasks.
+	instruments struct {
+		tracer   trace.Tracer
+		tasks    metric.Int64Counter
+		active   metric.Int64UpDownCounter
+		duration metric.Float64Histogram
+		name     string
+	}
+)
+
+// WithTracerProvider sets the provider of the spans, the global one by default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(b *builder) {
+		b.tracers = provider
+	}
+}
+
+// WithMeterProvider sets the provider of the metrics, the global one by default.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(b *builder) {
+		b.meters = provider
+	}
+}
+
+// WithSpanName sets the name of the span of the task, "pusher.task" by default.
+func WithSpanName(name string) Option {
+	return func(b *builder) {
+		b.name = name
+	}
+}
+
+// New creates the Interceptor (see pusher.WithInterceptors) that starts the span
+// of every task, so the outgoing calls of the Target traced by the context are
+// its children. The span tells the Worker, the Scenario and the sequence number
+// of the task and records its error. Besides, the Interceptor publishes
+// the metrics of the tasks:
+//
+//   - pusher.tasks counts the finished tasks by their outcome, success or failure
+//   - pusher.tasks.active is the amount of the running tasks
+//   - pusher.task.duration is the histogram of the task latencies in seconds
+//
+// The error tells which metrics can't be created, the Interceptor is usable
+// anyway: such metrics aren't published.
+func New(options ...Option) (pusher.Interceptor, error) {
+	build := &builder{tracers: otel.GetTracerProvider(), meters: otel.GetMeterProvider(), name: defaultSpanName}
+
+	for _, option := range options {
+		option(build)
+	}
+
+	var (
+		meter = build.meters.Meter(scope)
+		errs  = make([]error, 0)
+		tools = &instruments{
+			tracer:   build.tracers.Tracer(scope),
+			tasks:    nil,
+			active:   nil,
+			duration: nil,
+			name:     build.name,
+		}
+		err error
+	)
+
+	tools.tasks, err = meter.Int64Counter("pusher.tasks",
+		metric.WithDescription("Amount of the finished tasks by their outcome."),
+		metric.WithUnit("{task}"),
+	)
+	errs = append(errs, err)
+
+	tools.active, err = meter.Int64UpDownCounter("pusher.tasks.active",
+		metric.WithDescription("Amount of the running tasks."),
+		metric.WithUnit("{task}"),
+	)
+	errs = append(errs, err)
+
+	tools.duration, err = meter.Float64Histogram("pusher.task.duration",
+		metric.WithDescription("Latency of the tasks."),
+		metric.WithUnit("s"),
+	)
+	errs = append(errs, err)
+
+	return tools.intercept, errors.Join(errs...)
+}
+
+// intercept traces and measures a single task.
+func (i *instruments) intercept(
+	ctx context.Context,
+	worker *pusher.Worker,
+	task *pusher.Gossip,
+	call pusher.Target,
+) (pusher.Result, error) {
+	var (
+		labels = []attribute.KeyValue{WorkerKey.String(worker.String()), ScenarioKey.String(task.Scenario)}
+		common = metric.WithAttributes(labels...)
+	)
+
+	ctx, span := i.tracer.Start(ctx, i.name,
+		trace.WithAttributes(labels...),
+		trace.WithAttributes(SeqKey.Int64(int64(task.Seq))), //nolint:gosec // the tasks are less than MaxInt64
+	)
+	defer span.End()
+
+	i.active.Add(ctx, 1, common)
+	defer i.active.Add(ctx, -1, common)
+
+	start := time.Now()
+	result, err := call(ctx)
+	latency := time.Since(start)
+
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	i.tasks.Add(ctx, 1, common, metric.WithAttributes(OutcomeKey.String(outcome)))
+	i.duration.Record(ctx, latency.Seconds(), common)
+
+	return result, err
+}
@@ -0,0 +1,193 @@
+package otelx_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/internal/testkit"
+	"github.com/therenotomorrow/pusher/otelx"
+)
+
+type telemetry struct {
+	spans   *tracetest.InMemoryExporter
+	reader  *sdkmetric.ManualReader
+	tracers *sdktrace.TracerProvider
+	meters  *sdkmetric.MeterProvider
+}
+
+func setup(t *testing.T) *telemetry {
+	t.Helper()
+
+	var (
+		spans  = tracetest.NewInMemoryExporter()
+		reader = sdkmetric.NewManualReader()
+	)
+
+	return &telemetry{
+		spans:   spans,
+		reader:  reader,
+		tracers: sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans)),
+		meters:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	}
+}
+
+func (tel *telemetry) metrics(t *testing.T) map[string]metricdata.Aggregation {
+	t.Helper()
+
+	var data metricdata.ResourceMetrics
+
+	require.NoError(t, tel.reader.Collect(t.Context(), &data))
+
+	metrics := make(map[string]metricdata.Aggregation)
+
+	for _, scope := range data.ScopeMetrics {
+		for _, metric := range scope.Metrics {
+			metrics[metric.Name] = metric.Data
+		}
+	}
+
+	return metrics
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tel    = setup(t)
+		target = func(ctx context.Context) (pusher.Result, error) {
+			// the outgoing call of the target
+			_, span := tel.tracers.Tracer("target").Start(ctx, "call")
+			defer span.End()
+
+			return nil, testkit.ErrOops
+		}
+	)
+
+	interceptor, err := otelx.New(
+		otelx.WithTracerProvider(tel.tracers),
+		otelx.WithMeterProvider(tel.meters),
+		otelx.WithSpanName("task"),
+	)
+
+	require.NoError(t, err)
+
+	worker := pusher.Hire("tracer", nil,
+		pusher.WithMix(pusher.Scenario{Target: target, Name: "browse", Weight: 1}),
+		pusher.WithInterceptors(interceptor),
+		pusher.WithTasks(3),
+	)
+
+	_, err = worker.Work(t.Context(), pusher.Steady(1000))
+
+	require.NoError(t, err)
+
+	spans := tel.spans.GetSpans()
+	tasks := make(map[trace.SpanID]tracetest.SpanStub)
+
+	for _, span := range spans {
+		if span.Name == "task" {
+			tasks[span.SpanContext.SpanID()] = span
+		}
+	}
+
+	require.Len(t, spans, 6)
+	require.Len(t, tasks, 3)
+
+	seqs := make([]int64, 0)
+
+	for _, span := range spans {
+		if span.Name != "call" {
+			continue
+		}
+
+		task, ok := tasks[span.Parent.SpanID()]
+
+		require.True(t, ok, "the call must be the child of the task")
+		assert.Equal(t, codes.Error, task.Status.Code)
+		assert.Equal(t, "oops", task.Status.Description)
+		assert.Len(t, task.Events, 1) // the recorded error
+
+		attrs := attribute.NewSet(task.Attributes...)
+
+		ident, _ := attrs.Value(otelx.WorkerKey)
+		scenario, _ := attrs.Value(otelx.ScenarioKey)
+		seq, _ := attrs.Value(otelx.SeqKey)
+
+		assert.Equal(t, "tracer", ident.AsString())
+		assert.Equal(t, "browse", scenario.AsString())
+
+		seqs = append(seqs, seq.AsInt64())
+	}
+
+	assert.ElementsMatch(t, []int64{1, 2, 3}, seqs)
+
+	metrics := tel.metrics(t)
+
+	finished, ok := metrics["pusher.tasks"].(metricdata.Sum[int64])
+
+	require.True(t, ok)
+	require.Len(t, finished.DataPoints, 1)
+	assert.Equal(t, int64(3), finished.DataPoints[0].Value)
+
+	outcome, _ := finished.DataPoints[0].Attributes.Value(otelx.OutcomeKey)
+
+	assert.Equal(t, "failure", outcome.AsString())
+
+	active, ok := metrics["pusher.tasks.active"].(metricdata.Sum[int64])
+
+	require.True(t, ok)
+	require.Len(t, active.DataPoints, 1)
+	assert.Zero(t, active.DataPoints[0].Value)
+
+	duration, ok := metrics["pusher.task.duration"].(metricdata.Histogram[float64])
+
+	require.True(t, ok)
+	require.Len(t, duration.DataPoints, 1)
+	assert.Equal(t, uint64(3), duration.DataPoints[0].Count)
+}
+
+func TestNewSuccess(t *testing.T) {
+	t.Parallel()
+
+	tel := setup(t)
+
+	interceptor, err := otelx.New(otelx.WithTracerProvider(tel.tracers), otelx.WithMeterProvider(tel.meters))
+
+	require.NoError(t, err)
+
+	target := func(context.Context) (pusher.Result, error) { return testkit.Result("done"), nil }
+
+	_, err = pusher.Work(pusher.Steady(1000), 0, target, pusher.WithInterceptors(interceptor), pusher.WithTasks(2))
+
+	require.NoError(t, err)
+
+	spans := tel.spans.GetSpans()
+
+	require.Len(t, spans, 2)
+
+	for _, span := range spans {
+		assert.Equal(t, "pusher.task", span.Name)
+		assert.Equal(t, codes.Unset, span.Status.Code)
+		assert.Empty(t, span.Events)
+	}
+
+	finished, ok := tel.metrics(t)["pusher.tasks"].(metricdata.Sum[int64])
+
+	require.True(t, ok)
+	require.Len(t, finished.DataPoints, 1)
+
+	outcome, _ := finished.DataPoints[0].Attributes.Value(otelx.OutcomeKey)
+
+	assert.Equal(t, "success", outcome.AsString())
+}
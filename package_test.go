@@ -2,7 +2,6 @@ package pusher_test
 
 import (
 	"context"
-	"errors"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -87,18 +86,57 @@ func (s *sentry) Stop() {
 	<-s.done
 }
 
-func runner(target pusher.Target, offers ...pusher.Offer) (*pusher.Worker, func(ctx context.Context, rps int) error) {
-	worker := pusher.Hire("", target, offers...)
-	run := func(ctx context.Context, rps int) error {
-		err := worker.Work(ctx, rps)
+type recorder struct {
+	gossips []*pusher.Gossip
+	mutex   sync.Mutex
+}
 
-		switch {
-		case errors.Is(err, context.Canceled):
-		case errors.Is(err, context.DeadlineExceeded):
-			return nil
-		}
+func newRecorder() *recorder {
+	return &recorder{gossips: make([]*pusher.Gossip, 0), mutex: sync.Mutex{}}
+}
 
-		return ex.Conv(err)
+func (r *recorder) Listen(_ context.Context, _ *pusher.Worker, gossips <-chan *pusher.Gossip) {
+	for gossip := range gossips {
+		r.mutex.Lock()
+		r.gossips = append(r.gossips, gossip)
+		r.mutex.Unlock()
+	}
+}
+
+func (r *recorder) Stop() {}
+
+func (r *recorder) Gossips() []*pusher.Gossip {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.gossips
+}
+
+// gauge remembers the buffer size of the channel it listens to.
+type gauge struct {
+	size     atomic.Int64
+	received atomic.Int64
+}
+
+func (g *gauge) Listen(_ context.Context, _ *pusher.Worker, gossips <-chan *pusher.Gossip) {
+	g.size.Store(int64(cap(gossips)))
+
+	for range gossips {
+		g.received.Add(1)
+	}
+}
+
+func (g *gauge) Stop() {}
+
+func runner(
+	target pusher.Target,
+	offers ...pusher.Offer,
+) (*pusher.Worker, func(ctx context.Context, profile pusher.Profile) error) {
+	worker := pusher.Hire("", target, offers...)
+	run := func(ctx context.Context, profile pusher.Profile) error {
+		_, err := worker.Work(ctx, profile)
+
+		return err
 	}
 
 	return worker, run
@@ -110,6 +148,12 @@ func noop() pusher.Target {
 	}
 }
 
+func broken() pusher.Target {
+	return func(_ context.Context) (pusher.Result, error) {
+		return nil, ex.ErrUnexpected
+	}
+}
+
 func awaitable() pusher.Target {
 	return func(ctx context.Context) (pusher.Result, error) {
 		<-ctx.Done()
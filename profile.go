@@ -0,0 +1,322 @@
+package pusher
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// resolution is the longest step of the pacer. When the rate is low (or zero)
+// the profile is consulted at least this often, so an upcoming growth of the
+// rate isn't missed while waiting for a distant tick.
+const resolution = 10 * time.Millisecond
+
+type (
+	// Profile describes how the rate of a Worker changes over time.
+	Profile interface {
+		// At returns the desired requests per second after the elapsed time
+		// since the beginning of the work. Non-positive values pause the load.
+		At(elapsed time.Duration) float64
+	}
+
+	// Finite is the Profile that ends, e.g. the Stages: Work returns as soon
+	// as the Profile is done, without waiting for the deadline of the context.
+	Finite interface {
+		Profile
+		// Done tells whether the Profile is over after the elapsed time.
+		Done(elapsed time.Duration) bool
+	}
+
+	// ProfileFunc is an adapter to allow the use of ordinary functions as Profile.
+	ProfileFunc func(elapsed time.Duration) float64
+
+	// Rate is the constant rate Profile: Freq requests per the Per period.
+	// It's able to express both slow background traffic like one request
+	// every 5 seconds (Rate{Freq: 1, Per: 5 * time.Second}) and fractional
+	// rates like 12.5 RPS (Rate{Freq: 25, Per: 2 * time.Second}).
+	Rate struct {
+		Freq int
+		Per  time.Duration
+	}
+
+	// Stage is a part of the Stages profile that lasts for the Duration.
+	Stage struct {
+		Profile  Profile
+		Duration time.Duration
+	}
+
+	// plan is the Profile of the Stages.
+	plan []Stage
+
+	// broken is the Profile made of the invalid arguments, it pauses the load
+	// and Work refuses to run it with the error.
+	broken struct {
+		err error
+	}
+
+	// pacer turns a Profile into the sequence of the intended ticks.
+	pacer struct {
+		profile Profile
+		arrival Arrival
+		rnd     *rand.Rand
+		moment  time.Time
+		begin   time.Time
+		// credit is a part of the next tick already earned by the previous steps.
+		credit float64
+		// need is the credit required for the next tick, it's drawn from the Arrival.
+		need float64
+		// interval is set for the Rate profile, so its ticks are planned directly.
+		interval time.Duration
+	}
+)
+
+// At calls f(elapsed).
+func (f ProfileFunc) At(elapsed time.Duration) float64 {
+	return f(elapsed)
+}
+
+// Steady creates the Rate of the given requests per second.
+func Steady(rps int) Rate {
+	return Rate{Freq: rps, Per: time.Second}
+}
+
+// Every creates the Rate of one request per the given interval.
+func Every(interval time.Duration) Rate {
+	return Rate{Freq: 1, Per: interval}
+}
+
+// At returns the same requests per second for any moment.
+func (r Rate) At(_ time.Duration) float64 {
+	if r.Per <= 0 {
+		return 0
+	}
+
+	return float64(r.Freq) / r.Per.Seconds()
+}
+
+// Interval returns the time between two ticks of the Rate.
+func (r Rate) Interval() time.Duration {
+	if r.Freq < 1 {
+		return 0
+	}
+
+	return r.Per / time.Duration(r.Freq)
+}
+
+func (r Rate) String() string {
+	return fmt.Sprintf("%d/%s", r.Freq, r.Per)
+}
+
+// constant returns the Rate of the Profile given either by the value or by the pointer.
+func constant(profile Profile) (Rate, bool) {
+	switch rate := profile.(type) {
+	case Rate:
+		return rate, true
+	case *Rate:
+		if rate != nil {
+			return *rate, true
+		}
+	}
+
+	return Rate{}, false
+}
+
+// Ramp creates a Profile that linearly changes the rate from one value
+// to another during the given time and keeps the last value after it.
+func Ramp(from, to float64, over time.Duration) Profile {
+	return ProfileFunc(func(elapsed time.Duration) float64 {
+		if elapsed >= over {
+			return to
+		}
+
+		return from + (to-from)*float64(elapsed)/float64(over)
+	})
+}
+
+// Steps creates a staircase Profile that starts from one rate
+// and changes it by the step every given time, the time must be positive.
+func Steps(from, step float64, every time.Duration) Profile {
+	if every <= 0 {
+		return broken{err: ErrInvalidProfile.Reason("steps must last a positive time")}
+	}
+
+	return ProfileFunc(func(elapsed time.Duration) float64 {
+		return from + step*float64(elapsed/every)
+	})
+}
+
+// Sine creates a Profile that oscillates around the base rate with
+// the given amplitude and period, the period must be positive.
+func Sine(base, amplitude float64, period time.Duration) Profile {
+	if period <= 0 {
+		return broken{err: ErrInvalidProfile.Reason("sine period must be positive")}
+	}
+
+	return ProfileFunc(func(elapsed time.Duration) float64 {
+		phase := 2 * math.Pi * float64(elapsed) / float64(period)
+
+		return base + amplitude*math.Sin(phase)
+	})
+}
+
+// Spike creates a Profile that holds the base rate except for the peak rate
+// that starts at the given moment and lasts for the hold time.
+func Spike(base, peak float64, at, hold time.Duration) Profile {
+	return ProfileFunc(func(elapsed time.Duration) float64 {
+		if elapsed >= at && elapsed < at+hold {
+			return peak
+		}
+
+		return base
+	})
+}
+
+// Stages creates a Profile that runs the stages one after another. Every stage
+// sees the time elapsed since its own beginning. The Profile is Finite: the work
+// is over after the last stage. Every stage must have the Profile.
+func Stages(stages ...Stage) Profile {
+	for _, stage := range stages {
+		if stage.Profile == nil {
+			return broken{err: ErrInvalidProfile.Reason("stage has no profile")}
+		}
+
+		if fault, ok := stage.Profile.(broken); ok {
+			return fault
+		}
+	}
+
+	return plan(stages)
+}
+
+// At returns the rate of the current stage, zero after the last one.
+func (p plan) At(elapsed time.Duration) float64 {
+	for _, stage := range p {
+		if elapsed < stage.Duration {
+			return stage.Profile.At(elapsed)
+		}
+
+		elapsed -= stage.Duration
+	}
+
+	return 0
+}
+
+// Done tells whether the last stage is over.
+func (p plan) Done(elapsed time.Duration) bool {
+	for _, stage := range p {
+		elapsed -= stage.Duration
+	}
+
+	return elapsed >= 0
+}
+
+// At returns zero, the broken Profile never fires.
+func (b broken) At(_ time.Duration) float64 {
+	return 0
+}
+
+// newPacer creates a pacer that starts the schedule from the given moment.
+func newPacer(profile Profile, arrival Arrival, rnd *rand.Rand, begin time.Time) *pacer {
+	pace := &pacer{
+		profile:  profile,
+		arrival:  arrival,
+		rnd:      rnd,
+		moment:   begin,
+		begin:    begin,
+		credit:   0,
+		need:     0,
+		interval: 0,
+	}
+
+	if rate, ok := constant(profile); ok {
+		pace.interval = rate.Interval()
+	}
+
+	pace.need = pace.draw()
+
+	return pace
+}
+
+// next returns the moment to wait for and whether a task should be started
+// at that moment or the profile just needs to be consulted again.
+func (p *pacer) next() (time.Time, bool) {
+	if p.interval > 0 {
+		p.moment = p.moment.Add(max(p.arrival(p.interval, p.rnd), 0))
+
+		return p.moment, true
+	}
+
+	var (
+		rate = p.profile.At(p.moment.Sub(p.begin))
+		gap  = time.Duration(math.MaxInt64)
+	)
+
+	// the non-finite rate pauses the load like the non-positive one
+	if math.IsNaN(rate) || math.IsInf(rate, 0) {
+		rate = 0
+	}
+
+	if rate > 0 {
+		gap = max(time.Duration((p.need-p.credit)*float64(time.Second)/rate), 0)
+	}
+
+	if gap > resolution {
+		p.moment = p.moment.Add(resolution)
+		p.credit += max(rate, 0) * resolution.Seconds()
+
+		return p.moment, false
+	}
+
+	p.moment = p.moment.Add(gap)
+	p.credit = 0
+	p.need = p.draw()
+
+	return p.moment, true
+}
+
+// over tells whether the Finite profile is done by the current moment of the schedule.
+func (p *pacer) over() bool {
+	finite, ok := p.profile.(Finite)
+
+	return ok && finite.Done(p.moment.Sub(p.begin))
+}
+
+// draw returns the credit needed for the next tick. The Arrival is asked
+// for an interval at the rate of one tick per second, so the ticks of any
+// Profile follow the same distribution scaled by its current rate.
+func (p *pacer) draw() float64 {
+	return max(p.arrival(time.Second, p.rnd), 0).Seconds()
+}
+
+// capacity returns the size of a listener channel buffer: enough to hold
+// the events for a second at the peak rate of the profile over the run
+// without blocking, but no more than the limit. The run of the unknown length
+// is sampled for the horizon, the short peaks between the samples may be missed.
+// The non-finite rates pause the load, so they don't count. The AfterStep events
+// of the Flow share the buffer, so the flows of many steps may meet the backpressure
+// earlier, but it holds the worker only after the Target returns.
+func capacity(profile Profile, span time.Duration) int {
+	const (
+		samples = 1000
+		horizon = time.Hour
+		limit   = 1 << 16
+	)
+
+	if span <= 0 {
+		span = horizon
+	}
+
+	peak := 1.0
+
+	for sample := range samples + 1 {
+		rate := profile.At(span / samples * time.Duration(sample))
+		if math.IsNaN(rate) || math.IsInf(rate, 0) {
+			continue
+		}
+
+		peak = max(peak, rate)
+	}
+
+	return min(double*int(math.Ceil(min(peak, limit))), limit)
+}
@@ -0,0 +1,131 @@
+package pusher_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+func TestProfiles(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		profile pusher.Profile
+		elapsed time.Duration
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{name: "steady", args: args{profile: pusher.Steady(42), elapsed: time.Hour}, want: 42},
+		{name: "func", args: args{profile: pusher.ProfileFunc(time.Duration.Seconds), elapsed: time.Minute}, want: 60},
+		{name: "ramp start", args: args{profile: pusher.Ramp(10, 110, time.Minute), elapsed: 0}, want: 10},
+		{name: "ramp slow", args: args{profile: pusher.Ramp(0, 0.5, time.Minute), elapsed: 30 * time.Second}, want: 0.25},
+		{name: "ramp middle", args: args{profile: pusher.Ramp(10, 110, time.Minute), elapsed: 30 * time.Second}, want: 60},
+		{name: "ramp after", args: args{profile: pusher.Ramp(10, 110, time.Minute), elapsed: time.Hour}, want: 110},
+		{name: "ramp down", args: args{profile: pusher.Ramp(100, 0, time.Minute), elapsed: 45 * time.Second}, want: 25},
+		{name: "steps first", args: args{profile: pusher.Steps(10, 5, time.Minute), elapsed: 59 * time.Second}, want: 10},
+		{name: "steps third", args: args{profile: pusher.Steps(10, 5, time.Minute), elapsed: 2 * time.Minute}, want: 20},
+		{name: "sine zero", args: args{profile: pusher.Sine(100, 50, time.Minute), elapsed: 0}, want: 100},
+		{name: "sine peak", args: args{profile: pusher.Sine(100, 50, time.Minute), elapsed: 15 * time.Second}, want: 150},
+		{name: "sine bottom", args: args{profile: pusher.Sine(100, 50, time.Minute), elapsed: 45 * time.Second}, want: 50},
+		{name: "spike before", args: args{profile: pusher.Spike(10, 500, time.Minute, time.Second), elapsed: 0}, want: 10},
+		{
+			name: "spike inside",
+			args: args{profile: pusher.Spike(10, 500, time.Minute, time.Second), elapsed: time.Minute},
+			want: 500,
+		},
+		{
+			name: "spike after",
+			args: args{profile: pusher.Spike(10, 500, time.Minute, time.Second), elapsed: time.Minute + time.Second},
+			want: 10,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := test.args.profile.At(test.args.elapsed)
+
+			assert.InDelta(t, test.want, got, 1e-9)
+		})
+	}
+}
+
+func TestRate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		str      string
+		rate     pusher.Rate
+		rps      float64
+		interval time.Duration
+	}{
+		{name: "steady", rate: pusher.Steady(50), rps: 50, interval: 20 * time.Millisecond, str: "50/1s"},
+		{name: "every", rate: pusher.Every(5 * time.Second), rps: 0.2, interval: 5 * time.Second, str: "1/5s"},
+		{
+			name:     "fractional",
+			rate:     pusher.Rate{Freq: 25, Per: 2 * time.Second},
+			rps:      12.5,
+			interval: 80 * time.Millisecond,
+			str:      "25/2s",
+		},
+		{name: "zero", rate: pusher.Steady(0), rps: 0, interval: 0, str: "0/1s"},
+		{name: "no period", rate: pusher.Every(0), rps: 0, interval: 0, str: "1/0s"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.InDelta(t, test.rps, test.rate.At(time.Hour), 1e-9)
+			assert.Equal(t, test.interval, test.rate.Interval())
+			assert.Equal(t, test.str, test.rate.String())
+		})
+	}
+}
+
+func TestStages(t *testing.T) {
+	t.Parallel()
+
+	profile := pusher.Stages(
+		pusher.Stage{Profile: pusher.Ramp(0, 100, time.Minute), Duration: time.Minute},
+		pusher.Stage{Profile: pusher.Steady(100), Duration: time.Hour},
+		pusher.Stage{Profile: pusher.Ramp(100, 0, time.Minute), Duration: time.Minute},
+	)
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		want    float64
+	}{
+		{name: "ramp up", elapsed: 30 * time.Second, want: 50},
+		{name: "hold", elapsed: time.Minute, want: 100},
+		{name: "ramp down", elapsed: time.Hour + time.Minute + 30*time.Second, want: 50},
+		{name: "finished", elapsed: time.Hour + 2*time.Minute, want: 0},
+	}
+
+	finite, ok := profile.(pusher.Finite)
+
+	require.True(t, ok)
+	assert.False(t, finite.Done(time.Hour+2*time.Minute-time.Nanosecond))
+	assert.True(t, finite.Done(time.Hour+2*time.Minute))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := profile.At(test.elapsed)
+
+			assert.InDelta(t, test.want, got, 1e-9)
+		})
+	}
+}
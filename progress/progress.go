@@ -0,0 +1,303 @@
+// Package progress provides the Gossiper that prints the live progress
+// of the run, so the long runs are not silent until they finish.
+package progress
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/internal/hdr"
+)
+
+const (
+	defaultInterval = time.Second
+	defaultWindow   = 10 * time.Second
+	p95             = 0.95
+)
+
+type (
+	// Clock tells the time to the Printer, the tests may fake it.
+	Clock interface {
+		// Now returns the current time.
+		Now() time.Time
+		// Ticker delivers the ticks every given duration until it's stopped.
+		Ticker(every time.Duration) (ticks <-chan time.Time, stop func())
+	}
+
+	// Printer is a Gossiper that prints a progress line to the writer every interval:
+	//
+	//	elapsed 12s, remaining 48s, rps 49.80, in-flight 3, success 580, errors 12, canceled 0, p95 123.456ms
+	//
+	// The remaining time comes from the deadline of the run, it's omitted for the runs
+	// without one. The rps is measured over the last interval and the p95 over the last window.
+	// The line is printed from its own goroutine, so a slow writer never blocks the worker,
+	// and the write errors are ignored. A single Printer may be shared by several workers,
+	// e.g. with pusher.Farm, the last line is printed when all of them are done.
+	Printer struct {
+		clock     Clock
+		writer    io.Writer
+		begin     time.Time
+		deadline  time.Time
+		last      time.Time
+		quit      chan struct{}
+		done      chan struct{}
+		ring      []*hdr.Histogram
+		interval  time.Duration
+		window    time.Duration
+		listeners int
+		inFlight  int64
+		success   int64
+		failed    int64
+		canceled  int64
+		recent    int64
+		mutex     sync.Mutex
+	}
+
+	// Option is a functional option for configuring the Printer.
+	Option func(p *Printer)
+
+	// wall is the Clock of the real time.
+	wall struct{}
+)
+
+// WithInterval sets how often the line is printed, a second by default.
+func WithInterval(interval time.Duration) Option {
+	return func(p *Printer) {
+		if interval <= 0 {
+			interval = defaultInterval
+		}
+
+		p.interval = interval
+	}
+}
+
+// WithWindow sets how far back the p95 looks, ten seconds by default.
+// It's rounded up to the whole amount of the intervals.
+func WithWindow(window time.Duration) Option {
+	return func(p *Printer) {
+		if window <= 0 {
+			window = defaultWindow
+		}
+
+		p.window = window
+	}
+}
+
+// WithClock sets the Clock of the Printer, the real time by default.
+func WithClock(clock Clock) Option {
+	return func(p *Printer) {
+		if clock == nil {
+			clock = wall{}
+		}
+
+		p.clock = clock
+	}
+}
+
+// New creates the Printer that writes to the given writer, e.g. os.Stderr.
+func New(writer io.Writer, options ...Option) *Printer {
+	printer := &Printer{
+		clock:     wall{},
+		writer:    writer,
+		begin:     time.Time{},
+		deadline:  time.Time{},
+		last:      time.Time{},
+		quit:      nil,
+		done:      nil,
+		ring:      nil,
+		interval:  defaultInterval,
+		window:    defaultWindow,
+		listeners: 0,
+		inFlight:  0,
+		success:   0,
+		failed:    0,
+		canceled:  0,
+		recent:    0,
+		mutex:     sync.Mutex{},
+	}
+
+	for _, option := range options {
+		option(printer)
+	}
+
+	return printer
+}
+
+// Listen counts the gossips until the channel is closed.
+func (p *Printer) Listen(ctx context.Context, _ *pusher.Worker, gossips <-chan *pusher.Gossip) {
+	var running int64
+
+	p.join(ctx)
+	defer func() { p.leave(running) }()
+
+	for gossip := range gossips {
+		switch {
+		case gossip.BeforeTarget():
+			running++
+		case gossip.AfterTarget():
+			running--
+		}
+
+		p.record(gossip)
+	}
+}
+
+// Stop does nothing, the last line is printed when the last worker is done.
+func (p *Printer) Stop() {}
+
+// join starts the printing with the first worker.
+func (p *Printer) join(ctx context.Context) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.listeners == 0 {
+		p.deadline = time.Time{}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && deadline.After(p.deadline) {
+		p.deadline = deadline
+	}
+
+	p.listeners++
+	if p.listeners > 1 {
+		return
+	}
+
+	size := max(int((p.window+p.interval-1)/p.interval), 1)
+
+	p.begin = p.clock.Now()
+	p.last = p.begin
+	p.ring = make([]*hdr.Histogram, size)
+	p.inFlight, p.success, p.failed, p.canceled, p.recent = 0, 0, 0, 0, 0
+
+	for idx := range p.ring {
+		p.ring[idx] = hdr.New()
+	}
+
+	p.quit = make(chan struct{})
+	p.done = make(chan struct{})
+
+	go p.loop(p.quit, p.done)
+}
+
+// leave forgets the tasks of the worker cut off by the end of the work
+// and prints the last line with the last worker.
+func (p *Printer) leave(running int64) {
+	p.mutex.Lock()
+
+	p.inFlight -= running
+	p.listeners--
+
+	if p.listeners > 0 {
+		p.mutex.Unlock()
+
+		return
+	}
+
+	quit, done := p.quit, p.done
+
+	p.mutex.Unlock()
+
+	close(quit)
+	<-done
+
+	p.print(p.clock.Now())
+}
+
+// loop prints the line on every tick until it's quit.
+func (p *Printer) loop(quit, done chan struct{}) {
+	defer close(done)
+
+	ticks, stop := p.clock.Ticker(p.interval)
+	defer stop()
+
+	for {
+		select {
+		case moment := <-ticks:
+			p.print(moment)
+		case <-quit:
+			return
+		}
+	}
+}
+
+// record counts the gossip.
+func (p *Printer) record(gossip *pusher.Gossip) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	switch {
+	case gossip.BeforeTarget():
+		p.inFlight++
+	case gossip.Canceled():
+		p.canceled++
+	case gossip.AfterTarget():
+		p.inFlight--
+		p.recent++
+
+		if gossip.Error != nil {
+			p.failed++
+		} else {
+			p.success++
+		}
+
+		p.ring[0].Record(gossip.Latency())
+	}
+}
+
+// print writes the line of the given moment.
+func (p *Printer) print(moment time.Time) {
+	_, _ = io.WriteString(p.writer, p.line(moment))
+}
+
+// line renders the progress at the given moment and starts the next interval.
+func (p *Printer) line(moment time.Time) string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var (
+		text strings.Builder
+		hist = hdr.New()
+		rps  float64
+	)
+
+	for _, part := range p.ring {
+		hist.Merge(part)
+	}
+
+	if passed := moment.Sub(p.last); passed > 0 {
+		rps = float64(p.recent) / passed.Seconds()
+	}
+
+	fmt.Fprintf(&text, "elapsed %s", moment.Sub(p.begin).Round(time.Second))
+
+	if !p.deadline.IsZero() {
+		fmt.Fprintf(&text, ", remaining %s", max(p.deadline.Sub(moment), 0).Round(time.Second))
+	}
+
+	fmt.Fprintf(&text, ", rps %.2f, in-flight %d, success %d, errors %d, canceled %d, p95 %s\n",
+		rps, max(p.inFlight, 0), p.success, p.failed, p.canceled, hist.Quantile(p95).Round(time.Microsecond))
+
+	// the oldest interval leaves the window
+	copy(p.ring[1:], p.ring)
+	p.ring[0] = hdr.New()
+	p.last = moment
+	p.recent = 0
+
+	return text.String()
+}
+
+func (wall) Now() time.Time {
+	return time.Now()
+}
+
+func (wall) Ticker(every time.Duration) (<-chan time.Time, func()) {
+	ticker := time.NewTicker(every)
+
+	return ticker.C, ticker.Stop
+}
@@ -0,0 +1,204 @@
+package progress_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/internal/testkit"
+	"github.com/therenotomorrow/pusher/progress"
+)
+
+// clock is the Clock that ticks only when the test says so.
+type clock struct {
+	now   time.Time
+	ticks chan time.Time
+	mutex sync.Mutex
+}
+
+func newClock(now time.Time) *clock {
+	return &clock{now: now, ticks: make(chan time.Time), mutex: sync.Mutex{}}
+}
+
+func (c *clock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+func (c *clock) Ticker(time.Duration) (<-chan time.Time, func()) {
+	return c.ticks, func() {}
+}
+
+// tick moves the clock and delivers the tick.
+func (c *clock) tick(now time.Time) {
+	c.mutex.Lock()
+	c.now = now
+	c.mutex.Unlock()
+
+	c.ticks <- now
+}
+
+// lines is the writer that hands every line over to the test.
+type lines chan string
+
+func (l lines) Write(line []byte) (int, error) {
+	l <- string(line)
+
+	return len(line), nil
+}
+
+// stuck is the writer that hands every line over to the test and hangs until it's released.
+type stuck struct {
+	lines   chan string
+	release chan struct{}
+}
+
+func (s stuck) Write(line []byte) (int, error) {
+	s.lines <- string(line)
+	<-s.release
+
+	return len(line), nil
+}
+
+// send delivers the gossips and makes sure the Printer has counted them all.
+func send(gossips chan<- *pusher.Gossip, events ...*pusher.Gossip) {
+	for _, event := range events {
+		gossips <- event
+	}
+
+	// the Printer skips the steps, so the delivered one means the previous ones are counted
+	gossips <- testkit.Gossip(pusher.AfterStep, "", time.Now(), 0, nil)
+}
+
+func TestPrinter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		begin   = time.Date(2025, time.March, 8, 12, 0, 0, 0, time.UTC)
+		fake    = newClock(begin)
+		out     = make(lines, 1)
+		gossips = make(chan *pusher.Gossip)
+		done    = make(chan struct{})
+		printer = progress.New(out, progress.WithClock(fake), progress.WithWindow(2*time.Second))
+	)
+
+	ctx, cancel := context.WithDeadline(t.Context(), begin.Add(10*time.Second))
+	defer cancel()
+
+	go func() {
+		defer close(done)
+
+		printer.Listen(ctx, pusher.Hire("alice", nil), gossips)
+		printer.Stop()
+	}()
+
+	send(gossips,
+		testkit.Gossip(pusher.BeforeTarget, "", begin, 0, nil),
+		testkit.Gossip(pusher.BeforeTarget, "", begin, 0, nil),
+		testkit.Gossip(pusher.BeforeTarget, "", begin, 0, nil),
+		testkit.Gossip(pusher.AfterTarget, "", begin, 10*time.Millisecond, nil),
+		testkit.Gossip(pusher.AfterTarget, "", begin, 20*time.Millisecond, testkit.ErrOops),
+		testkit.Gossip(pusher.Canceled, "", begin, 0, nil),
+	)
+
+	fake.tick(begin.Add(2 * time.Second))
+	assert.Equal(t, "elapsed 2s, remaining 8s, rps 1.00, in-flight 1, success 1, errors 1, canceled 1, p95 20ms\n", <-out)
+
+	send(gossips, testkit.Gossip(pusher.AfterTarget, "", begin, 30*time.Millisecond, nil))
+
+	fake.tick(begin.Add(3 * time.Second))
+	assert.Equal(t, "elapsed 3s, remaining 7s, rps 1.00, in-flight 0, success 2, errors 1, canceled 1, p95 30ms\n", <-out)
+
+	fake.tick(begin.Add(4 * time.Second))
+	assert.Equal(t, "elapsed 4s, remaining 6s, rps 0.00, in-flight 0, success 2, errors 1, canceled 1, p95 30ms\n", <-out)
+
+	fake.tick(begin.Add(5 * time.Second))
+	assert.Equal(t, "elapsed 5s, remaining 5s, rps 0.00, in-flight 0, success 2, errors 1, canceled 1, p95 0s\n", <-out)
+
+	// the task cut off by the end of the work is not in flight anymore
+	send(gossips, testkit.Gossip(pusher.BeforeTarget, "", begin, 0, nil))
+	close(gossips)
+
+	fake.mutex.Lock()
+	fake.now = begin.Add(12 * time.Second)
+	fake.mutex.Unlock()
+
+	assert.Equal(t, "elapsed 12s, remaining 0s, rps 0.00, in-flight 0, success 2, errors 1, canceled 1, p95 0s\n", <-out)
+	<-done
+}
+
+func TestPrinterSlowWriter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		begin   = time.Now()
+		fake    = newClock(begin)
+		out     = stuck{lines: make(chan string), release: make(chan struct{})}
+		gossips = make(chan *pusher.Gossip)
+		done    = make(chan struct{})
+		printer = progress.New(out, progress.WithClock(fake))
+	)
+
+	go func() {
+		defer close(done)
+
+		printer.Listen(t.Context(), pusher.Hire("alice", nil), gossips)
+	}()
+
+	send(gossips)
+	fake.tick(begin.Add(time.Second))
+
+	assert.Equal(t, "elapsed 1s, rps 0.00, in-flight 0, success 0, errors 0, canceled 0, p95 0s\n", <-out.lines)
+
+	// the writer hangs, but the gossips are still counted
+	for range 100 {
+		send(gossips,
+			testkit.Gossip(pusher.BeforeTarget, "", begin, 0, nil),
+			testkit.Gossip(pusher.AfterTarget, "", begin, time.Millisecond, nil),
+		)
+	}
+
+	close(gossips)
+	close(out.release)
+
+	assert.Equal(t, "elapsed 1s, rps 0.00, in-flight 0, success 100, errors 0, canceled 0, p95 1ms\n", <-out.lines)
+	<-done
+}
+
+func TestPrinterWork(t *testing.T) {
+	t.Parallel()
+
+	var (
+		text    strings.Builder
+		printer = progress.New(&text, progress.WithInterval(50*time.Millisecond), progress.WithClock(nil))
+		target  = func(_ context.Context) (pusher.Result, error) { return testkit.Result("done"), nil }
+		workers = []*pusher.Worker{
+			pusher.Hire("alice", target, pusher.WithGossips(printer)),
+			pusher.Hire("bob", target, pusher.WithGossips(printer)),
+		}
+	)
+
+	report, err := pusher.Farm(pusher.Steady(100), 300*time.Millisecond, workers)
+
+	require.NoError(t, err)
+
+	got := strings.Split(strings.TrimSpace(text.String()), "\n")
+
+	require.GreaterOrEqual(t, len(got), 3)
+
+	for _, line := range got {
+		assert.Contains(t, line, "remaining")
+	}
+
+	assert.Contains(t, got[len(got)-1], "in-flight 0")
+	assert.Contains(t, got[len(got)-1], "errors 0")
+	assert.Positive(t, report.Completed)
+}
@@ -0,0 +1,200 @@
+// Package promx provides the Gossiper that exposes the live metrics
+// of the workers in the Prometheus text format.
+package promx
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+const (
+	defaultNamespace = "pusher"
+	defaultPath      = "/metrics"
+)
+
+type (
+	// Exporter is a Gossiper that maintains the Prometheus metrics of every
+	// Worker it listens to, labelled by the Worker.String and the Scenario
+	// (the names are given for the default namespace):
+	//
+	//   - pusher_events_total{when} counts the Gossip events by their stage
+	//   - pusher_errors_total{kind} counts the failed tasks by the kind of the error
+	//   - pusher_in_flight is the amount of the running tasks
+	//   - pusher_latency_seconds is the histogram of the task latencies
+	//
+	// The metrics live as long as the Exporter, so a single one may be shared
+	// by several workers and several runs, e.g. with pusher.Farm.
+	Exporter struct {
+		registry *prometheus.Registry
+		events   *prometheus.CounterVec
+		errors   *prometheus.CounterVec
+		inFlight *prometheus.GaugeVec
+		latency  *prometheus.HistogramVec
+		kind     pusher.Kind
+		path     string
+	}
+
+	// Option is a functional option for configuring the Exporter.
+	Option func(b *builder)
+
+	// builder holds the configuration of the Exporter.
+	builder struct {
+		registry  *prometheus.Registry
+		kind      pusher.Kind
+		namespace string
+		path      string
+		buckets   []float64
+	}
+)
+
+// WithNamespace sets the prefix of the metric names, "pusher" by default.
+func WithNamespace(namespace string) Option {
+	return func(b *builder) {
+		b.namespace = namespace
+	}
+}
+
+// WithPath sets the path the Handler serves the metrics on, "/metrics" by default.
+func WithPath(path string) Option {
+	return func(b *builder) {
+		b.path = path
+	}
+}
+
+// WithBuckets sets the upper bounds of the latency histogram in seconds,
+// prometheus.DefBuckets by default.
+func WithBuckets(buckets ...float64) Option {
+	return func(b *builder) {
+		b.buckets = buckets
+	}
+}
+
+// WithKind sets the way the errors are labelled, the default one is pusher.RootKind.
+func WithKind(kind pusher.Kind) Option {
+	return func(b *builder) {
+		if kind == nil {
+			kind = pusher.RootKind
+		}
+
+		b.kind = kind
+	}
+}
+
+// WithRegistry registers the metrics in the given registry instead of the own
+// one, e.g. to serve them together with the metrics of the application.
+func WithRegistry(registry *prometheus.Registry) Option {
+	return func(b *builder) {
+		b.registry = registry
+	}
+}
+
+// New creates the Exporter and registers its metrics. It panics if the metrics
+// are already registered in the registry given by WithRegistry.
+func New(options ...Option) *Exporter {
+	build := &builder{
+		registry:  nil,
+		kind:      pusher.RootKind,
+		namespace: defaultNamespace,
+		path:      defaultPath,
+		buckets:   prometheus.DefBuckets,
+	}
+
+	for _, option := range options {
+		option(build)
+	}
+
+	if build.registry == nil {
+		build.registry = prometheus.NewRegistry()
+	}
+
+	opts := func(name, help string) prometheus.Opts {
+		return prometheus.Opts{Namespace: build.namespace, Subsystem: "", Name: name, Help: help, ConstLabels: nil}
+	}
+
+	exporter := &Exporter{
+		registry: build.registry,
+		events: prometheus.NewCounterVec(
+			prometheus.CounterOpts(opts("events_total", "Amount of the gossip events by their stage.")),
+			[]string{"worker", "scenario", "when"},
+		),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts(opts("errors_total", "Amount of the failed tasks by the kind of the error.")),
+			[]string{"worker", "scenario", "kind"},
+		),
+		inFlight: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts(opts("in_flight", "Amount of the running tasks.")),
+			[]string{"worker", "scenario"},
+		),
+		latency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{ //nolint:exhaustruct // the native histograms are off
+				Namespace: build.namespace,
+				Name:      "latency_seconds",
+				Help:      "Latency of the tasks.",
+				Buckets:   build.buckets,
+			},
+			[]string{"worker", "scenario"},
+		),
+		kind: build.kind,
+		path: build.path,
+	}
+
+	build.registry.MustRegister(exporter.events, exporter.errors, exporter.inFlight, exporter.latency)
+
+	return exporter
+}
+
+// Handler serves the metrics on the path given by WithPath, e.g. to start
+// the server by http.ListenAndServe(":2112", exporter.Handler()).
+func (e *Exporter) Handler() http.Handler {
+	var (
+		mux  = http.NewServeMux()
+		opts = promhttp.HandlerOpts{} //nolint:exhaustruct // the defaults are fine
+	)
+
+	mux.Handle(e.path, promhttp.HandlerFor(e.registry, opts))
+
+	return mux
+}
+
+// Listen updates the metrics until the channel is closed.
+func (e *Exporter) Listen(_ context.Context, worker *pusher.Worker, gossips <-chan *pusher.Gossip) {
+	var (
+		ident   = worker.String()
+		running = make(map[string]int)
+	)
+
+	// the tasks cut off by the end of the work have no AfterTarget events
+	defer func() {
+		for scenario, amount := range running {
+			e.inFlight.WithLabelValues(ident, scenario).Sub(float64(amount))
+		}
+	}()
+
+	for gossip := range gossips {
+		e.events.WithLabelValues(ident, gossip.Scenario, string(gossip.When)).Inc()
+
+		switch {
+		case gossip.BeforeTarget():
+			running[gossip.Scenario]++
+
+			e.inFlight.WithLabelValues(ident, gossip.Scenario).Inc()
+		case gossip.AfterTarget():
+			running[gossip.Scenario]--
+
+			e.inFlight.WithLabelValues(ident, gossip.Scenario).Dec()
+			e.latency.WithLabelValues(ident, gossip.Scenario).Observe(gossip.Latency().Seconds())
+
+			if gossip.Error != nil {
+				e.errors.WithLabelValues(ident, gossip.Scenario, e.kind(gossip.Error)).Inc()
+			}
+		}
+	}
+}
+
+// Stop does nothing, the metrics are updated while listening and kept after.
+func (e *Exporter) Stop() {}
@@ -0,0 +1,142 @@
+package promx_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/internal/testkit"
+	"github.com/therenotomorrow/pusher/promx"
+)
+
+func scrape(t *testing.T, handler http.Handler, path string) (int, string) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL+path, http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(body)
+}
+
+func TestExporter(t *testing.T) {
+	t.Parallel()
+
+	exporter := promx.New()
+
+	_, err := pusher.Work(pusher.Steady(1000), 0, testkit.Flaky(), pusher.WithGossips(exporter), pusher.WithTasks(10))
+
+	require.NoError(t, err)
+
+	status, body := scrape(t, exporter.Handler(), "/metrics")
+
+	assert.Equal(t, http.StatusOK, status)
+
+	for _, line := range []string{
+		`pusher_events_total{scenario="",when="before-target",worker="judas"} 10`,
+		`pusher_events_total{scenario="",when="after-target",worker="judas"} 10`,
+		`pusher_errors_total{kind="oops",scenario="",worker="judas"} 5`,
+		`pusher_in_flight{scenario="",worker="judas"} 0`,
+		`pusher_latency_seconds_count{scenario="",worker="judas"} 10`,
+	} {
+		assert.Contains(t, body, line)
+	}
+}
+
+func TestExporterOptions(t *testing.T) {
+	t.Parallel()
+
+	exporter := promx.New(
+		promx.WithNamespace("load"),
+		promx.WithPath("/custom"),
+		promx.WithBuckets(0.5, 1),
+		promx.WithKind(func(error) string { return "any" }),
+	)
+
+	worker := pusher.Hire("somebody", nil,
+		pusher.WithMix(pusher.Scenario{Target: testkit.Flaky(), Name: "browse", Weight: 1}),
+		pusher.WithGossips(exporter),
+		pusher.WithTasks(4),
+	)
+
+	_, err := worker.Work(t.Context(), pusher.Steady(1000))
+
+	require.NoError(t, err)
+
+	status, _ := scrape(t, exporter.Handler(), "/metrics")
+
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, body := scrape(t, exporter.Handler(), "/custom")
+
+	assert.Equal(t, http.StatusOK, status)
+
+	for _, line := range []string{
+		`load_events_total{scenario="browse",when="after-target",worker="somebody"} 4`,
+		`load_errors_total{kind="any",scenario="browse",worker="somebody"} 2`,
+		`load_latency_seconds_bucket{scenario="browse",worker="somebody",le="0.5"} 4`,
+	} {
+		assert.Contains(t, body, line)
+	}
+}
+
+func TestExporterInFlight(t *testing.T) {
+	t.Parallel()
+
+	var (
+		exporter = promx.New()
+		started  = make(chan struct{})
+		target   = func(ctx context.Context) (pusher.Result, error) {
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+
+			<-ctx.Done()
+
+			return nil, ctx.Err()
+		}
+		worker = pusher.Hire("", target, pusher.WithGossips(exporter))
+	)
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		_, _ = worker.Work(ctx, pusher.Steady(100))
+	}()
+
+	<-started
+
+	_, body := scrape(t, exporter.Handler(), "/metrics")
+
+	assert.NotContains(t, body, `pusher_in_flight{scenario="",worker="judas"} 0`)
+
+	cancel()
+	<-done
+
+	_, body = scrape(t, exporter.Handler(), "/metrics")
+
+	assert.Contains(t, body, `pusher_in_flight{scenario="",worker="judas"} 0`)
+}
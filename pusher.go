@@ -3,6 +3,7 @@ package pusher
 import (
 	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -18,11 +19,24 @@ func Hire(ident string, target Target, offers ...Offer) *Worker {
 		ident:  cmp.Or(ident, defaultIdent),
 		target: target,
 		config: config{
-			overtime:  defaultOvertime,
-			listeners: make([]Gossiper, 0),
+			arrival:      Constant(),
+			thinking:     Constant(),
+			overtime:     defaultOvertime,
+			listeners:    make([]Gossiper, 0),
+			thresholds:   make([]Threshold, 0),
+			aborts:       make([]Abort, 0),
+			scenarios:    make([]Scenario, 0),
+			interceptors: make([]Interceptor, 0),
+			seed:         0,
+			think:        0,
+			grace:        defaultGrace,
+			iterations:   0,
+			tasks:        0,
+			results:      0,
 		},
 		wlb:  nil, // initialized after all options are applied
 		wait: sync.WaitGroup{},
+		chat: sync.WaitGroup{},
 		busy: atomic.Bool{},
 	}
 
@@ -39,40 +53,86 @@ func Hire(ident string, target Target, offers ...Offer) *Worker {
 }
 
 // Work is a convenience wrapper that creates and runs a single Worker
-// for a specified duration.
-func Work(rps int, duration time.Duration, target Target, offers ...Offer) error {
+// for a specified duration. Zero or negative duration means no time limit,
+// so the Worker should be stopped by the quota (see WithTasks and WithResults).
+// It returns the Report of the run and nil error when the run lasts as planned.
+func Work(profile Profile, duration time.Duration, target Target, offers ...Offer) (Report, error) {
 	worker := Hire(defaultIdent, target, offers...)
 
-	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	ctx, cancel := deadline(duration)
 	defer cancel()
 
-	return worker.Work(ctx, rps)
+	return worker.Work(ctx, profile)
+}
+
+// Crew is a convenience wrapper that creates a single Worker and runs
+// the given amount of its virtual users for a specified duration.
+// Zero or negative duration means no time limit, like for Work.
+func Crew(users int, duration time.Duration, target Target, offers ...Offer) (Report, error) {
+	worker := Hire(defaultIdent, target, offers...)
+
+	ctx, cancel := deadline(duration)
+	defer cancel()
+
+	return worker.Crew(ctx, users)
 }
 
 // Farm runs a set of pre-configured workers in parallel.
 // It uses an errgroup to manage their lifecycle, ensuring that if one worker
-// fails, the context is canceled for all.
-func Farm(rps int, duration time.Duration, workers []*Worker) error {
-	ctx, cancel := context.WithTimeout(context.Background(), duration)
+// fails, the context is canceled for all and the error of that worker is returned.
+// Every worker checks its own thresholds (see WithThresholds), Farm joins the breaches.
+// The Report sums up all the workers and holds the report of every one of them.
+// Zero or negative duration means no time limit, like for Work.
+func Farm(profile Profile, duration time.Duration, workers []*Worker) (Report, error) {
+	ctx, cancel := deadline(duration)
 	defer cancel()
 
-	group, gtx := errgroup.WithContext(ctx)
+	return FarmContext(ctx, profile, workers)
+}
 
-	for _, worker := range workers {
+// FarmContext runs the workers like Farm till the context is done, e.g. canceled
+// by the interrupt signal. Like for Work, the elapsed deadline of the context
+// is the planned end of the run.
+func FarmContext(ctx context.Context, profile Profile, workers []*Worker) (Report, error) {
+	var (
+		begin      = time.Now()
+		reports    = make([]Report, len(workers))
+		breaches   = make([]error, len(workers))
+		group, gtx = errgroup.WithContext(ctx)
+	)
+
+	for idx, worker := range workers {
 		group.Go(func() error {
-			return worker.Work(gtx, rps)
+			report, err := worker.Work(gtx, profile)
+			reports[idx] = report
+
+			// the breach is a verdict on the finished work, so it mustn't stop the others
+			if errors.Is(err, ErrThresholdBreached) {
+				breaches[idx] = ex.Error(worker.String()).Because(err)
+
+				return nil
+			}
+
+			return err
 		})
 	}
 
-	return ex.Conv(group.Wait())
+	err := group.Wait()
+	report := combine(reports, time.Since(begin))
+
+	if err != nil {
+		return report, ex.Conv(err)
+	}
+
+	return report, errors.Join(breaches...)
 }
 
 // Force is a high-level wrapper that creates a specified number of workers
 // with the same configuration and runs them as a Farm.
 // Be careful - overtime will be populated by all workers at once.
 // Text me if you need another behaviour.
-func Force(rps int, duration time.Duration, target Target, offers ...Offer) func(amount int) error {
-	return func(amount int) error {
+func Force(profile Profile, duration time.Duration, target Target, offers ...Offer) func(amount int) (Report, error) {
+	return func(amount int) (Report, error) {
 		workers := make([]*Worker, amount)
 		for ident := range workers {
 			worker := Hire(fmt.Sprintf("force #%d", ident), target, offers...)
@@ -80,6 +140,15 @@ func Force(rps int, duration time.Duration, target Target, offers ...Offer) func
 			workers[ident] = worker
 		}
 
-		return Farm(rps, duration, workers)
+		return Farm(profile, duration, workers)
 	}
 }
+
+// deadline creates a context for the duration, zero or negative one means no time limit.
+func deadline(duration time.Duration) (context.Context, context.CancelFunc) {
+	if duration <= 0 {
+		return context.WithCancel(context.Background())
+	}
+
+	return context.WithTimeout(context.Background(), duration)
+}
@@ -18,11 +18,21 @@ func TestHireWithDefaults(t *testing.T) {
 
 	got := worker.Config()
 	want := pusher.Config{
-		Ident:       "judas",
-		Listeners:   make([]pusher.Gossiper, 0),
-		Overtime:    1_000_000,
-		WLBCapacity: 1_000_000,
-		Busy:        false,
+		Ident:        "judas",
+		Listeners:    make([]pusher.Gossiper, 0),
+		Thresholds:   make([]pusher.Threshold, 0),
+		Aborts:       make([]pusher.Abort, 0),
+		Scenarios:    make([]pusher.Scenario, 0),
+		Interceptors: make([]pusher.Interceptor, 0),
+		Overtime:     1_000_000,
+		WLBCapacity:  1_000_000,
+		Seed:         0,
+		Think:        0,
+		Grace:        5 * time.Second,
+		Iterations:   0,
+		Tasks:        0,
+		Results:      0,
+		Busy:         false,
 	}
 
 	assert.Equal(t, want, got)
@@ -41,11 +51,21 @@ func TestHireWithOffers(t *testing.T) {
 
 	got := worker.Config()
 	want := pusher.Config{
-		Ident:       ident,
-		Listeners:   gossipers,
-		Overtime:    limit,
-		WLBCapacity: limit,
-		Busy:        false,
+		Ident:        ident,
+		Listeners:    gossipers,
+		Thresholds:   make([]pusher.Threshold, 0),
+		Aborts:       make([]pusher.Abort, 0),
+		Scenarios:    make([]pusher.Scenario, 0),
+		Interceptors: make([]pusher.Interceptor, 0),
+		Overtime:     limit,
+		WLBCapacity:  limit,
+		Seed:         0,
+		Think:        0,
+		Grace:        5 * time.Second,
+		Iterations:   0,
+		Tasks:        0,
+		Results:      0,
+		Busy:         false,
 	}
 
 	assert.Equal(t, want, got)
@@ -63,11 +83,21 @@ func TestHireNegativeOvertime(t *testing.T) {
 
 	got := worker.Config()
 	want := pusher.Config{
-		Ident:       ident,
-		Listeners:   make([]pusher.Gossiper, 0),
-		Overtime:    -42,
-		WLBCapacity: 0,
-		Busy:        false,
+		Ident:        ident,
+		Listeners:    make([]pusher.Gossiper, 0),
+		Thresholds:   make([]pusher.Threshold, 0),
+		Aborts:       make([]pusher.Abort, 0),
+		Scenarios:    make([]pusher.Scenario, 0),
+		Interceptors: make([]pusher.Interceptor, 0),
+		Overtime:     -42,
+		WLBCapacity:  0,
+		Seed:         0,
+		Think:        0,
+		Grace:        5 * time.Second,
+		Iterations:   0,
+		Tasks:        0,
+		Results:      0,
+		Busy:         false,
 	}
 
 	assert.Equal(t, want, got)
@@ -89,7 +119,7 @@ func TestWork(t *testing.T) {
 		name string
 		args args
 	}{
-		{name: "success", args: args{rps: 1}, want: want{err: context.DeadlineExceeded}},
+		{name: "success", args: args{rps: 1}, want: want{err: nil}},
 		{name: "failure", args: args{rps: -1}, want: want{err: pusher.ErrInvalidRPS}},
 	}
 
@@ -97,7 +127,7 @@ func TestWork(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
 
-			err := pusher.Work(test.args.rps, time.Second, noop())
+			_, err := pusher.Work(pusher.Steady(test.args.rps), time.Second, noop())
 
 			require.ErrorIs(t, err, test.want.err)
 		})
@@ -125,7 +155,7 @@ func TestFarm(t *testing.T) {
 		{
 			name: "success",
 			args: args{worker: pusher.Hire("", awaitable())},
-			want: want{calls: 22, strict: false, err: context.DeadlineExceeded},
+			want: want{calls: 22, strict: false, err: nil},
 		},
 		{
 			name: "failure",
@@ -154,7 +184,7 @@ func TestFarm(t *testing.T) {
 			)
 
 			workers = append(workers, test.args.worker)
-			err := pusher.Farm(rps, duration, workers)
+			_, err := pusher.Farm(pusher.Steady(rps), duration, workers)
 
 			require.ErrorIs(t, err, test.want.err)
 
@@ -167,6 +197,27 @@ func TestFarm(t *testing.T) {
 	}
 }
 
+func TestFarmContext(t *testing.T) {
+	t.Parallel()
+
+	var (
+		obs     = newObserver()
+		workers = []*pusher.Worker{
+			pusher.Hire("#1", noop(), pusher.WithGossips(obs)),
+			pusher.Hire("#2", noop()),
+		}
+	)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	report, err := pusher.FarmContext(ctx, pusher.Steady(100), workers)
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, report.Workers, 2)
+	assert.InDelta(t, 20, report.Completed, 6)
+}
+
 func TestForce(t *testing.T) {
 	t.Parallel()
 
@@ -185,7 +236,7 @@ func TestForce(t *testing.T) {
 		want want
 		args args
 	}{
-		{name: "success", args: args{rps: 10}, want: want{calls: 22, strict: false, err: context.DeadlineExceeded}},
+		{name: "success", args: args{rps: 10}, want: want{calls: 22, strict: false, err: nil}},
 		{name: "failure", args: args{rps: -42}, want: want{calls: 0, strict: true, err: pusher.ErrInvalidRPS}},
 	}
 
@@ -204,13 +255,13 @@ func TestForce(t *testing.T) {
 			)
 
 			run := pusher.Force(
-				test.args.rps,
+				pusher.Steady(test.args.rps),
 				duration,
 				noop(),
 				pusher.WithGossips(obs1, obs2, obs3, obs),
 				pusher.WithOvertime(limit),
 			)
-			err := run(amount)
+			_, err := run(amount)
 
 			require.ErrorIs(t, err, test.want.err)
 
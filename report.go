@@ -0,0 +1,141 @@
+package pusher
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/therenotomorrow/pusher/internal/hdr"
+)
+
+type (
+	// Report summarizes a single run of the Worker or the whole Farm.
+	Report struct {
+		// Ident is the identity of the Worker, it's empty for the Farm.
+		Ident string
+		// Workers holds the reports of every worker of the Farm, it's empty for a single Worker.
+		Workers []Report
+		// Duration is the wall-clock time from the start of the run till the last task finished.
+		Duration time.Duration
+		// RPS is the achieved rate: the amount of completed tasks per second.
+		RPS float64
+		// Scheduled is the amount of ticks given by the Profile (or iterations of the Crew).
+		Scheduled uint64
+		// Started is the amount of tasks that called the Target.
+		Started uint64
+		// Completed is the amount of tasks whose Target returned, including the failed ones.
+		Completed uint64
+		// Canceled is the amount of ticks skipped because of the concurrency limit.
+		Canceled uint64
+		// Failed is the amount of tasks whose Target returned an error.
+		Failed uint64
+	}
+
+	// tally counts the tasks of a single run, it's shared by all the goroutines of the run.
+	tally struct {
+		hist *hdr.Histogram
+		// response is the histogram of the time from the tick, see Gossip.Response.
+		response *hdr.Histogram
+		// guard stops the run when the abort rules trip.
+		guard     *guard
+		begin     time.Time
+		end       time.Time
+		scheduled atomic.Uint64
+		started   atomic.Uint64
+		completed atomic.Uint64
+		canceled  atomic.Uint64
+		failed    atomic.Uint64
+	}
+)
+
+func (r Report) String() string {
+	return fmt.Sprintf(
+		"scheduled: %d, started: %d, completed: %d, canceled: %d, failed: %d, rps: %.2f, duration: %s",
+		r.Scheduled, r.Started, r.Completed, r.Canceled, r.Failed, r.RPS, r.Duration,
+	)
+}
+
+// combine sums up the reports of the Farm workers that ran for the given duration.
+func combine(reports []Report, duration time.Duration) Report {
+	farm := Report{
+		Ident:     "",
+		Workers:   reports,
+		Duration:  duration,
+		RPS:       0,
+		Scheduled: 0,
+		Started:   0,
+		Completed: 0,
+		Canceled:  0,
+		Failed:    0,
+	}
+
+	for _, report := range reports {
+		farm.Scheduled += report.Scheduled
+		farm.Started += report.Started
+		farm.Completed += report.Completed
+		farm.Canceled += report.Canceled
+		farm.Failed += report.Failed
+	}
+
+	farm.RPS = rps(farm.Completed, duration)
+
+	return farm
+}
+
+// rps returns the amount of tasks per second for the duration.
+func rps(tasks uint64, duration time.Duration) float64 {
+	if duration <= 0 {
+		return 0
+	}
+
+	return float64(tasks) / duration.Seconds()
+}
+
+// newTally creates a tally that starts counting right now.
+func newTally(guard *guard) *tally {
+	return &tally{
+		hist:      hdr.New(),
+		response:  hdr.New(),
+		guard:     guard,
+		begin:     time.Now(),
+		end:       time.Time{},
+		scheduled: atomic.Uint64{},
+		started:   atomic.Uint64{},
+		completed: atomic.Uint64{},
+		canceled:  atomic.Uint64{},
+		failed:    atomic.Uint64{},
+	}
+}
+
+// done counts the task described by the AfterTarget gossip.
+func (t *tally) done(gossip *Gossip) {
+	t.completed.Add(1)
+	t.hist.Record(gossip.Latency())
+	t.response.Record(gossip.Response())
+
+	if gossip.Error != nil {
+		t.failed.Add(1)
+	}
+
+	t.guard.check(gossip)
+}
+
+// report turns the tally into the Report of the Worker.
+func (t *tally) report(ident string) Report {
+	var (
+		duration  = t.end.Sub(t.begin)
+		completed = t.completed.Load()
+	)
+
+	return Report{
+		Ident:     ident,
+		Workers:   nil,
+		Duration:  duration,
+		RPS:       rps(completed, duration),
+		Scheduled: t.scheduled.Load(),
+		Started:   t.started.Load(),
+		Completed: completed,
+		Canceled:  t.canceled.Load(),
+		Failed:    t.failed.Load(),
+	}
+}
@@ -0,0 +1,304 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/therenotomorrow/ex"
+)
+
+const (
+	// ErrRegression is returned by Compare when the run is worse than the baseline
+	// beyond the tolerances, the reason lists every regression.
+	ErrRegression = ex.Error("run regressed")
+
+	// ErrInvalidMetric is returned by Compare when the Tolerance is given for the metric
+	// it can't judge, e.g. Slower for the rps or for the unknown metric.
+	ErrInvalidMetric = ex.Error("invalid metric")
+)
+
+// The metrics of the Breakdown the Tolerance is given for.
+const (
+	MetricP50       Metric = "p50"
+	MetricP90       Metric = "p90"
+	MetricP99       Metric = "p99"
+	MetricMean      Metric = "mean"
+	MetricMax       Metric = "max"
+	MetricErrorRate Metric = "error rate"
+	MetricRPS       Metric = "rps"
+)
+
+const (
+	// defaultSlower and defaultErrors are the tolerances of Compare without the given ones.
+	defaultSlower = 0.1
+	defaultErrors = 0.01
+)
+
+type (
+	// Metric names a single value of the Breakdown.
+	Metric string
+
+	// Tolerance is the allowed regression of a metric of the run against
+	// the baseline, e.g. "p99 latency is at most 10% slower". The zero Tolerance
+	// has no metric, Compare rejects it.
+	Tolerance struct {
+		// limit returns the worst acceptable value for the value of the baseline.
+		limit func(base float64) float64
+		// err tells why the Tolerance can't be used, Compare returns it.
+		err    error
+		metric Metric
+	}
+
+	// Comparison is the outcome of Compare: the metrics of the total and of every
+	// Scenario known to both runs, the ones of the baseline against the current ones.
+	Comparison struct {
+		Baseline string  `json:"baseline"`
+		Current  string  `json:"current"`
+		Deltas   []Delta `json:"deltas"`
+	}
+
+	// Delta is the change of a single metric, the latencies are in nanoseconds like the time.Duration.
+	Delta struct {
+		// Part is "total" or the name of the Scenario.
+		Part     string  `json:"part"`
+		Metric   Metric  `json:"metric"`
+		Baseline float64 `json:"baseline"`
+		Current  float64 `json:"current"`
+		// Limit is the worst acceptable value given by the Tolerance.
+		Limit     float64 `json:"limit"`
+		Regressed bool    `json:"regressed"`
+	}
+)
+
+// Slower allows the latency metric to grow by the share of the baseline value,
+// e.g. Slower(MetricP99, 0.1) fails the run that is more than 10% slower at p99.
+// Compare returns the ErrInvalidMetric for any other metric.
+func Slower(metric Metric, share float64) Tolerance {
+	tolerance := Tolerance{metric: metric, limit: func(base float64) float64 { return base * (1 + share) }, err: nil}
+	if !metric.latency() {
+		tolerance.err = ErrInvalidMetric.Reason(fmt.Sprintf("%q is not the latency", metric))
+	}
+
+	return tolerance
+}
+
+// MoreErrors allows the error rate to grow by the given points, from 0 to 1,
+// e.g. MoreErrors(0.01) fails the run that has 1% of the tasks failed more.
+func MoreErrors(points float64) Tolerance {
+	return Tolerance{metric: MetricErrorRate, limit: func(base float64) float64 { return base + points }, err: nil}
+}
+
+// LessThroughput allows the rps to drop by the share of the baseline value,
+// e.g. LessThroughput(0.05) fails the run that is more than 5% slower to complete the tasks.
+func LessThroughput(share float64) Tolerance {
+	return Tolerance{metric: MetricRPS, limit: func(base float64) float64 { return base * (1 - share) }, err: nil}
+}
+
+// Save writes the run as JSON, e.g. to keep it as the baseline of the next releases.
+func Save(writer io.Writer, run Run) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+
+	return ex.Conv(encoder.Encode(run))
+}
+
+// Load reads the run written by Save.
+func Load(reader io.Reader) (Run, error) {
+	var run Run
+
+	err := json.NewDecoder(reader).Decode(&run)
+	if err != nil {
+		return Run{}, ex.Conv(err)
+	}
+
+	return run, nil
+}
+
+// Compare checks the current run against the baseline one with the tolerances,
+// by default Slower(MetricP50, 0.1), Slower(MetricP99, 0.1) and MoreErrors(0.01).
+// It returns the Comparison of every metric of the tolerances and the ErrRegression
+// if any of them regressed beyond its tolerance, or the ErrInvalidMetric and no
+// Comparison if any of them can't judge its metric.
+func Compare(baseline, current Run, tolerances ...Tolerance) (Comparison, error) {
+	if len(tolerances) == 0 {
+		tolerances = []Tolerance{
+			Slower(MetricP50, defaultSlower),
+			Slower(MetricP99, defaultSlower),
+			MoreErrors(defaultErrors),
+		}
+	}
+
+	for _, tolerance := range tolerances {
+		if tolerance.limit == nil {
+			return Comparison{}, ErrInvalidMetric.Reason("not provided")
+		}
+
+		if tolerance.err != nil {
+			return Comparison{}, tolerance.err
+		}
+	}
+
+	var (
+		comparison  = Comparison{Baseline: baseline.Title, Current: current.Title, Deltas: make([]Delta, 0)}
+		regressions = make([]string, 0)
+		pairs       = [][2]Breakdown{{baseline.Total, current.Total}}
+	)
+
+	for _, base := range baseline.Scenarios {
+		for _, part := range current.Scenarios {
+			if part.Name == base.Name {
+				pairs = append(pairs, [2]Breakdown{base, part})
+			}
+		}
+	}
+
+	for _, pair := range pairs {
+		for _, tolerance := range tolerances {
+			delta := tolerance.compare(pair[0], pair[1])
+			comparison.Deltas = append(comparison.Deltas, delta)
+
+			if delta.Regressed {
+				regressions = append(regressions, delta.String())
+			}
+		}
+	}
+
+	if len(regressions) == 0 {
+		return comparison, nil
+	}
+
+	return comparison, ErrRegression.Reason(strings.Join(regressions, ", "))
+}
+
+// Diff writes the Comparison as the Markdown table, like Markdown does for the Run.
+func Diff(writer io.Writer, comparison Comparison) error {
+	var (
+		text strings.Builder
+		rows = make([][]string, 0, len(comparison.Deltas))
+	)
+
+	fmt.Fprintf(&text, "# %s against %s\n\n", comparison.Current, comparison.Baseline)
+
+	for _, delta := range comparison.Deltas {
+		verdict := "ok"
+		if delta.Regressed {
+			verdict = "regressed"
+		}
+
+		rows = append(rows, []string{
+			delta.Part,
+			string(delta.Metric),
+			delta.Metric.format(delta.Baseline),
+			delta.Metric.format(delta.Current),
+			delta.change(),
+			delta.Metric.format(delta.Limit),
+			verdict,
+		})
+	}
+
+	grid(&text, []string{"part", "metric", "baseline", "current", "change", "limit", "verdict"}, rows)
+
+	_, err := io.WriteString(writer, text.String())
+
+	return ex.Conv(err)
+}
+
+// String describes the regression, e.g. "total p99 150ms > 132ms".
+func (d Delta) String() string {
+	sign := ">"
+	if !d.Metric.higher() {
+		sign = "<"
+	}
+
+	return fmt.Sprintf("%s %s %s %s %s",
+		d.Part, d.Metric, d.Metric.format(d.Current), sign, d.Metric.format(d.Limit))
+}
+
+// change tells the relative change of the metric.
+func (d Delta) change() string {
+	if d.Baseline == 0 {
+		return "-"
+	}
+
+	return strconv.FormatFloat((d.Current-d.Baseline)/d.Baseline*ratio, 'f', 2, 64) + "%"
+}
+
+// compare makes the Delta of the metric of the current breakdown against the baseline one.
+func (t Tolerance) compare(base, current Breakdown) Delta {
+	delta := Delta{
+		Part:      current.Name,
+		Metric:    t.metric,
+		Baseline:  t.metric.value(base),
+		Current:   t.metric.value(current),
+		Limit:     0,
+		Regressed: false,
+	}
+
+	delta.Limit = t.limit(delta.Baseline)
+
+	if t.metric.higher() {
+		delta.Regressed = delta.Current > delta.Limit
+	} else {
+		delta.Regressed = delta.Current < delta.Limit
+	}
+
+	return delta
+}
+
+// value takes the metric of the breakdown.
+func (m Metric) value(part Breakdown) float64 {
+	switch m {
+	case MetricP50:
+		return float64(part.Latency.P50)
+	case MetricP90:
+		return float64(part.Latency.P90)
+	case MetricP99:
+		return float64(part.Latency.P99)
+	case MetricMean:
+		return float64(part.Latency.Mean)
+	case MetricMax:
+		return float64(part.Latency.Max)
+	case MetricErrorRate:
+		return part.ErrorRate
+	case MetricRPS:
+		return part.RPS
+	default:
+		return 0
+	}
+}
+
+// latency tells whether the metric is one of the latencies.
+func (m Metric) latency() bool {
+	switch m {
+	case MetricP50, MetricP90, MetricP99, MetricMean, MetricMax:
+		return true
+	case MetricErrorRate, MetricRPS:
+		return false
+	default:
+		return false
+	}
+}
+
+// higher tells whether the higher value of the metric is the worse one.
+func (m Metric) higher() bool {
+	return m != MetricRPS
+}
+
+// format renders the value of the metric for the humans.
+func (m Metric) format(value float64) string {
+	switch m {
+	case MetricErrorRate:
+		return strconv.FormatFloat(value*ratio, 'f', 2, 64) + "%"
+	case MetricRPS:
+		return strconv.FormatFloat(value, 'f', 2, 64)
+	case MetricP50, MetricP90, MetricP99, MetricMean, MetricMax:
+		return short(time.Duration(value))
+	default:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+}
@@ -0,0 +1,242 @@
+package report_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher/report"
+)
+
+// slower makes the run with the latencies longer by the factor, the error rate and the rps are set.
+func slower(run report.Run, factor float64, errorRate, rps float64) report.Run {
+	scale := func(part report.Breakdown) report.Breakdown {
+		part.Latency.P50 = time.Duration(float64(part.Latency.P50) * factor)
+		part.Latency.P99 = time.Duration(float64(part.Latency.P99) * factor)
+		part.ErrorRate = errorRate
+		part.RPS = rps
+
+		return part
+	}
+
+	run.Title = "current"
+	run.Total = scale(run.Total)
+	run.Scenarios = []report.Breakdown{scale(run.Scenarios[1]), scale(run.Scenarios[0])}
+
+	return run
+}
+
+func TestErrRegression(t *testing.T) {
+	t.Parallel()
+
+	assert.EqualError(t, report.ErrRegression, "run regressed")
+}
+
+func TestErrInvalidMetric(t *testing.T) {
+	t.Parallel()
+
+	assert.EqualError(t, report.ErrInvalidMetric, "invalid metric")
+}
+
+func TestSaveLoad(t *testing.T) {
+	t.Parallel()
+
+	var (
+		file bytes.Buffer
+		want = sample()
+	)
+
+	require.NoError(t, report.Save(&file, want))
+	assert.Contains(t, file.String(), "\n  \"title\": \"release <v1.2.3>\",\n")
+
+	got, err := report.Load(&file)
+
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = report.Load(strings.NewReader("{"))
+
+	require.Error(t, err)
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err     error
+		reason  string
+		deltas  int
+		regress int
+	}
+
+	tests := []struct {
+		name       string
+		tolerances []report.Tolerance
+		want       want
+		current    report.Run
+	}{
+		{
+			name:       "same",
+			current:    slower(sample(), 1, 0.125, 99.5),
+			tolerances: nil,
+			want:       want{err: nil, reason: "", deltas: 9, regress: 0},
+		},
+		{
+			name:       "within tolerance",
+			current:    slower(sample(), 1.09, 0.13, 99.5),
+			tolerances: nil,
+			want:       want{err: nil, reason: "", deltas: 9, regress: 0},
+		},
+		{
+			name:       "slower",
+			current:    slower(sample(), 1.2, 0.125, 99.5),
+			tolerances: nil,
+			want:       want{err: report.ErrRegression, reason: "total p99 1.481s > 1.358s", deltas: 9, regress: 6},
+		},
+		{
+			name:       "more errors",
+			current:    slower(sample(), 1, 0.5, 99.5),
+			tolerances: []report.Tolerance{report.MoreErrors(0.1)},
+			want: want{
+				err: report.ErrRegression, reason: "search error rate 50.00% > 22.50%", deltas: 3, regress: 3,
+			},
+		},
+		{
+			name:       "less throughput",
+			current:    slower(sample(), 1, 0.125, 50),
+			tolerances: []report.Tolerance{report.LessThroughput(0.1), report.Slower(report.MetricMax, 0)},
+			want:       want{err: report.ErrRegression, reason: "browse rps 50.00 < 89.55", deltas: 6, regress: 3},
+		},
+		{
+			name:       "other metrics",
+			current:    slower(sample(), 3, 0.125, 99.5),
+			tolerances: []report.Tolerance{report.Slower(report.MetricP90, 0), report.Slower(report.MetricMean, 0)},
+			want:       want{err: nil, reason: "", deltas: 6, regress: 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := report.Compare(sample(), test.current, test.tolerances...)
+
+			require.ErrorIs(t, err, test.want.err)
+
+			if test.want.reason != "" {
+				assert.Contains(t, err.Error(), test.want.reason)
+			}
+
+			assert.Equal(t, "release <v1.2.3>", got.Baseline)
+			assert.Equal(t, "current", got.Current)
+			assert.Len(t, got.Deltas, test.want.deltas)
+
+			var regress int
+
+			for _, delta := range got.Deltas {
+				if delta.Regressed {
+					regress++
+				}
+			}
+
+			assert.Equal(t, test.want.regress, regress)
+		})
+	}
+}
+
+func TestCompareInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		want      string
+		tolerance report.Tolerance
+	}{
+		{
+			name:      "rps",
+			tolerance: report.Slower(report.MetricRPS, 0.1),
+			want:      `invalid metric: "rps" is not the latency`,
+		},
+		{
+			name:      "error rate",
+			tolerance: report.Slower(report.MetricErrorRate, 0.1),
+			want:      `invalid metric: "error rate" is not the latency`,
+		},
+		{
+			name:      "unknown",
+			tolerance: report.Slower(report.Metric("p95"), 0.1),
+			want:      `invalid metric: "p95" is not the latency`,
+		},
+		{
+			name:      "zero value",
+			tolerance: report.Tolerance{},
+			want:      "invalid metric: not provided",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := report.Compare(sample(), sample(), report.MoreErrors(0.01), test.tolerance)
+
+			require.ErrorIs(t, err, report.ErrInvalidMetric)
+			require.EqualError(t, err, test.want)
+			assert.Empty(t, got.Deltas)
+		})
+	}
+}
+
+func TestCompareScenarios(t *testing.T) {
+	t.Parallel()
+
+	var (
+		baseline = sample()
+		current  = slower(sample(), 2, 0, 99.5)
+	)
+
+	// the new scenario has nothing to compare to
+	current.Scenarios[0].Name = "checkout"
+
+	got, err := report.Compare(baseline, current, report.Slower(report.MetricP50, 0.5))
+
+	require.ErrorIs(t, err, report.ErrRegression)
+	require.Len(t, got.Deltas, 2)
+	assert.Equal(t, "total", got.Deltas[0].Part)
+	assert.Equal(t, "browse", got.Deltas[1].Part)
+	assert.Equal(t, report.MetricP50, got.Deltas[1].Metric)
+	assert.InDelta(t, float64(10*time.Millisecond), got.Deltas[1].Baseline, 1)
+	assert.InDelta(t, float64(20*time.Millisecond), got.Deltas[1].Current, 1)
+	assert.InDelta(t, float64(15*time.Millisecond), got.Deltas[1].Limit, 1)
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	var text strings.Builder
+
+	baseline := sample()
+	baseline.Total.ErrorRate = 0
+
+	got, err := report.Compare(baseline, slower(sample(), 1.2, 0.125, 99.5),
+		report.Slower(report.MetricP50, 0.5),
+		report.MoreErrors(0.01),
+	)
+
+	require.ErrorIs(t, err, report.ErrRegression)
+	require.NoError(t, report.Diff(&text, got))
+
+	for _, want := range []string{
+		"# current against release <v1.2.3>\n",
+		"| part   | metric     | baseline | current | change | limit  | verdict   |",
+		"| total  | p50        | 10ms     | 12ms    | 20.00% | 15ms   | ok        |",
+		"| total  | error rate | 0.00%    | 12.50%  | -      | 1.00%  | regressed |",
+		"| browse | error rate | 12.50%   | 12.50%  | 0.00%  | 13.50% | ok        |",
+	} {
+		assert.Contains(t, text.String(), want)
+	}
+}
@@ -0,0 +1,271 @@
+// Package report collects the data of a run and renders it as a self-contained
+// HTML page with the charts or as the Markdown tables for the terminals
+// and the CI logs. The saved run is the baseline to find the regressions
+// of the next ones.
+package report
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/internal/hdr"
+	"github.com/therenotomorrow/pusher/internal/period"
+	"github.com/therenotomorrow/pusher/internal/timeline"
+)
+
+const defaultInterval = time.Second
+
+type (
+	// Recorder is a Gossiper that collects everything the report needs: the totals,
+	// the breakdowns per Worker and per Scenario, the errors by kind and the timeline.
+	// It's safe to share one Recorder between several workers, e.g. with pusher.Farm.
+	Recorder struct {
+		span      period.Span
+		kind      pusher.Kind
+		total     *tally
+		workers   map[string]*tally
+		scenarios map[string]*tally
+		errors    map[string]int64
+		history   *timeline.Timeline
+		interval  time.Duration
+		mutex     sync.Mutex
+	}
+
+	// Option is a functional option for configuring the Recorder.
+	Option func(r *Recorder)
+
+	// tally is the running breakdown of the tasks.
+	tally struct {
+		hist      *hdr.Histogram
+		completed int64
+		failed    int64
+		canceled  int64
+	}
+)
+
+// WithInterval sets the width of the windows of the timeline, a second by default.
+func WithInterval(interval time.Duration) Option {
+	return func(r *Recorder) {
+		if interval <= 0 {
+			interval = defaultInterval
+		}
+
+		r.interval = interval
+	}
+}
+
+// WithKind sets the way the errors are grouped, the default one is pusher.RootKind.
+func WithKind(kind pusher.Kind) Option {
+	return func(r *Recorder) {
+		if kind == nil {
+			kind = pusher.RootKind
+		}
+
+		r.kind = kind
+	}
+}
+
+// NewRecorder creates an empty Recorder.
+func NewRecorder(options ...Option) *Recorder {
+	recorder := &Recorder{
+		span:      period.Span{Begin: time.Time{}, End: time.Time{}},
+		kind:      pusher.RootKind,
+		total:     newTally(),
+		workers:   make(map[string]*tally),
+		scenarios: make(map[string]*tally),
+		errors:    make(map[string]int64),
+		history:   nil,
+		interval:  defaultInterval,
+		mutex:     sync.Mutex{},
+	}
+
+	for _, option := range options {
+		option(recorder)
+	}
+
+	recorder.history = timeline.New(recorder.interval)
+
+	return recorder
+}
+
+// Listen records the gossips until the channel is closed.
+func (r *Recorder) Listen(_ context.Context, worker *pusher.Worker, gossips <-chan *pusher.Gossip) {
+	ident := worker.String()
+
+	r.mark(time.Now())
+	defer func() { r.mark(time.Now()) }()
+
+	for gossip := range gossips {
+		if gossip.Canceled() || gossip.AfterTarget() {
+			r.record(ident, gossip)
+		}
+	}
+}
+
+// Stop has nothing to flush, the Run is complete once the workers are done.
+func (r *Recorder) Stop() {}
+
+// Run returns the data of the run recorded so far under the given title.
+func (r *Recorder) Run(title string) Run {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	duration := r.span.Duration()
+	run := Run{
+		Title:     title,
+		Begin:     r.span.Begin,
+		Duration:  duration,
+		Interval:  r.interval,
+		Total:     r.total.breakdown("total", duration),
+		Workers:   breakdowns(r.workers, duration),
+		Scenarios: breakdowns(r.scenarios, duration),
+		Errors:    make([]Failure, 0, len(r.errors)),
+		Timeline:  make([]Point, 0),
+	}
+
+	for kind, count := range r.errors {
+		run.Errors = append(run.Errors, Failure{Kind: kind, Count: count})
+	}
+
+	slices.SortFunc(run.Errors, func(a, b Failure) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Kind, b.Kind))
+	})
+
+	for _, win := range r.history.Windows() {
+		run.Timeline = append(run.Timeline, point(win, r.interval))
+	}
+
+	return run
+}
+
+// record adds the finished or the canceled task to the run.
+func (r *Recorder) record(ident string, gossip *pusher.Gossip) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tallies := []*tally{r.total, pick(r.workers, ident)}
+	if gossip.Scenario != "" {
+		tallies = append(tallies, pick(r.scenarios, gossip.Scenario))
+	}
+
+	moment := gossip.End
+	if gossip.Canceled() {
+		moment = gossip.Tick
+	}
+
+	win := r.history.Window(moment.Sub(r.span.Begin))
+
+	for _, count := range tallies {
+		count.add(gossip)
+	}
+
+	switch {
+	case gossip.Canceled():
+		win.Canceled++
+	case gossip.Error != nil:
+		win.Failed++
+		r.errors[r.kind(gossip.Error)]++
+
+		fallthrough
+	default:
+		win.Hist.Record(gossip.Latency())
+	}
+}
+
+// mark extends the span of the run with the given moment.
+func (r *Recorder) mark(moment time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.span.Mark(moment)
+}
+
+func newTally() *tally {
+	return &tally{hist: hdr.New(), completed: 0, failed: 0, canceled: 0}
+}
+
+// pick returns the tally of the given name, it's created if missing.
+func pick(tallies map[string]*tally, name string) *tally {
+	count, ok := tallies[name]
+	if !ok {
+		count = newTally()
+		tallies[name] = count
+	}
+
+	return count
+}
+
+// add counts the gossip.
+func (t *tally) add(gossip *pusher.Gossip) {
+	if gossip.Canceled() {
+		t.canceled++
+
+		return
+	}
+
+	t.completed++
+	if gossip.Error != nil {
+		t.failed++
+	}
+
+	t.hist.Record(gossip.Latency())
+}
+
+// breakdown summarizes the tally of the run lasted for the duration.
+func (t *tally) breakdown(name string, duration time.Duration) Breakdown {
+	breakdown := Breakdown{
+		Name:      name,
+		Completed: t.completed,
+		Failed:    t.failed,
+		Canceled:  t.canceled,
+		RPS:       0,
+		ErrorRate: 0,
+		Latency:   summarize(t.hist),
+	}
+
+	if duration > 0 {
+		breakdown.RPS = float64(t.completed) / duration.Seconds()
+	}
+
+	if t.completed > 0 {
+		breakdown.ErrorRate = float64(t.failed) / float64(t.completed)
+	}
+
+	return breakdown
+}
+
+// breakdowns summarizes the tallies sorted by their names.
+func breakdowns(tallies map[string]*tally, duration time.Duration) []Breakdown {
+	list := make([]Breakdown, 0, len(tallies))
+
+	for name, count := range tallies {
+		list = append(list, count.breakdown(name, duration))
+	}
+
+	slices.SortFunc(list, func(a, b Breakdown) int { return cmp.Compare(a.Name, b.Name) })
+
+	return list
+}
+
+// point takes the Point of the window summary.
+func point(summary timeline.Summary, interval time.Duration) Point {
+	return Point{
+		Offset:    summary.Offset,
+		Completed: summary.Completed,
+		Failed:    summary.Failed,
+		Canceled:  summary.Canceled,
+		RPS:       float64(summary.Completed) / interval.Seconds(),
+		Latency: Latency{
+			Min:  summary.Min,
+			Mean: summary.Mean,
+			P50:  summary.P50,
+			P90:  summary.P90,
+			P99:  summary.P99,
+			Max:  summary.Max,
+		},
+	}
+}
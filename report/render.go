@@ -0,0 +1,258 @@
+package report
+
+import (
+	_ "embed" // the template of the HTML report
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/therenotomorrow/ex"
+)
+
+const (
+	// width and height are the size of the charts of the HTML report.
+	width  = 800
+	height = 240
+	// ratio is the percent of the error rate.
+	ratio = 100
+)
+
+var (
+	//go:embed report.html
+	page string
+
+	layout = template.Must(template.New("report").Funcs(template.FuncMap{
+		"short":   short,
+		"percent": func(rate float64) string { return strconv.FormatFloat(rate*ratio, 'f', 2, 64) + "%" },
+		"rps":     func(rps float64) string { return strconv.FormatFloat(rps, 'f', 2, 64) },
+		"moment":  func(moment time.Time) string { return moment.Format(time.RFC3339) },
+	}).Parse(page))
+
+	// escape keeps the text of the Markdown cell in its cell: the pipes would
+	// split it and the line breaks would end the row.
+	escape = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ", "\r", " ")
+)
+
+type (
+	// chart is the SVG line chart of the timeline.
+	chart struct {
+		Title  string
+		Top    string
+		End    string
+		Series []series
+		Width  int
+		Height int
+	}
+
+	// series is a single line of the chart.
+	series struct {
+		Name   string
+		Color  string
+		Points string
+	}
+
+	// line describes the series to draw: the value of every Point.
+	line struct {
+		value func(point Point) float64
+		name  string
+		color string
+	}
+)
+
+// Markdown writes the summary of the run as the Markdown tables, they are
+// readable as a plain text too, e.g. in the terminal or the CI log.
+func Markdown(writer io.Writer, run Run) error {
+	var text strings.Builder
+
+	fmt.Fprintf(&text, "# %s\n\n", run.Title)
+	fmt.Fprintf(&text, "Began at %s, lasted %s.\n\n", run.Begin.Format(time.RFC3339), short(run.Duration))
+
+	text.WriteString("## Summary\n\n")
+	table(&text, []Breakdown{run.Total})
+
+	if len(run.Workers) > 0 {
+		text.WriteString("\n## Workers\n\n")
+		table(&text, run.Workers)
+	}
+
+	if len(run.Scenarios) > 0 {
+		text.WriteString("\n## Scenarios\n\n")
+		table(&text, run.Scenarios)
+	}
+
+	if len(run.Errors) > 0 {
+		rows := make([][]string, 0, len(run.Errors))
+		for _, failure := range run.Errors {
+			rows = append(rows, []string{failure.Kind, strconv.FormatInt(failure.Count, 10)})
+		}
+
+		text.WriteString("\n## Errors\n\n")
+		grid(&text, []string{"kind", "count"}, rows)
+	}
+
+	_, err := io.WriteString(writer, text.String())
+
+	return ex.Conv(err)
+}
+
+// HTML writes the self-contained page of the run: the tables and the charts
+// of the latency and the throughput over time, no scripts or external files.
+func HTML(writer io.Writer, run Run) error {
+	data := struct {
+		Charts  []chart
+		Summary []Breakdown
+		Run     Run
+	}{
+		Run:     run,
+		Summary: []Breakdown{run.Total},
+		Charts: []chart{
+			draw("Latency", run, func(top float64) string { return short(time.Duration(top)) },
+				line{name: "p50", color: "#2b8a3e", value: func(p Point) float64 { return float64(p.Latency.P50) }},
+				line{name: "p99", color: "#e67700", value: func(p Point) float64 { return float64(p.Latency.P99) }},
+				line{name: "max", color: "#c92a2a", value: func(p Point) float64 { return float64(p.Latency.Max) }},
+			),
+			draw("Throughput, per second", run,
+				func(top float64) string { return strconv.FormatFloat(top, 'f', 0, 64) },
+				line{name: "completed", color: "#1971c2", value: func(p Point) float64 { return p.RPS }},
+				line{name: "failed", color: "#c92a2a", value: func(p Point) float64 {
+					return float64(p.Failed) / run.Interval.Seconds()
+				}},
+				line{name: "canceled", color: "#868e96", value: func(p Point) float64 {
+					return float64(p.Canceled) / run.Interval.Seconds()
+				}},
+			),
+		},
+	}
+
+	return ex.Conv(layout.Execute(writer, data))
+}
+
+// table writes the breakdowns as the Markdown table.
+func table(text *strings.Builder, breakdowns []Breakdown) {
+	header := []string{
+		"name", "completed", "failed", "canceled", "rps", "error rate",
+		"min", "mean", "p50", "p90", "p99", "max",
+	}
+	rows := make([][]string, 0, len(breakdowns))
+
+	for _, part := range breakdowns {
+		rows = append(rows, []string{
+			part.Name,
+			strconv.FormatInt(part.Completed, 10),
+			strconv.FormatInt(part.Failed, 10),
+			strconv.FormatInt(part.Canceled, 10),
+			strconv.FormatFloat(part.RPS, 'f', 2, 64),
+			strconv.FormatFloat(part.ErrorRate*ratio, 'f', 2, 64) + "%",
+			short(part.Latency.Min),
+			short(part.Latency.Mean),
+			short(part.Latency.P50),
+			short(part.Latency.P90),
+			short(part.Latency.P99),
+			short(part.Latency.Max),
+		})
+	}
+
+	grid(text, header, rows)
+}
+
+// grid writes the Markdown table with the columns aligned for the plain text.
+func grid(text *strings.Builder, header []string, rows [][]string) {
+	widths := make([]int, len(header))
+	rows = slices.Clone(rows)
+
+	for idx, row := range rows {
+		cells := make([]string, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, escape.Replace(cell))
+		}
+
+		rows[idx] = cells
+	}
+
+	for _, row := range append([][]string{header}, rows...) {
+		for col, cell := range row {
+			widths[col] = max(widths[col], len(cell))
+		}
+	}
+
+	write := func(row []string) {
+		for col, cell := range row {
+			fmt.Fprintf(text, "| %-*s ", widths[col], cell)
+		}
+
+		text.WriteString("|\n")
+	}
+
+	write(header)
+
+	for col := range header {
+		fmt.Fprintf(text, "|%s", strings.Repeat("-", widths[col]+2)) //nolint:mnd // the spaces around the cell
+	}
+
+	text.WriteString("|\n")
+
+	for _, row := range rows {
+		write(row)
+	}
+}
+
+// draw makes the chart of the lines over the timeline of the run.
+func draw(title string, run Run, label func(top float64) string, lines ...line) chart {
+	var (
+		top  float64
+		last = len(run.Timeline) - 1
+	)
+
+	for _, point := range run.Timeline {
+		for _, line := range lines {
+			top = max(top, line.value(point))
+		}
+	}
+
+	plot := chart{
+		Title:  title,
+		Top:    label(top),
+		End:    short(run.Duration),
+		Series: make([]series, 0, len(lines)),
+		Width:  width,
+		Height: height,
+	}
+
+	for _, line := range lines {
+		points := make([]string, 0, len(run.Timeline))
+
+		for idx, point := range run.Timeline {
+			var x, y float64
+
+			if last > 0 {
+				x = float64(idx) / float64(last) * width
+			}
+
+			if top > 0 {
+				y = height - line.value(point)/top*height
+			}
+
+			points = append(points, strconv.FormatFloat(x, 'f', 1, 64)+","+strconv.FormatFloat(y, 'f', 1, 64))
+		}
+
+		plot.Series = append(plot.Series, series{Name: line.name, Color: line.color, Points: strings.Join(points, " ")})
+	}
+
+	return plot
+}
+
+// short rounds the duration for the humans, e.g. 1.234s or 12.345ms.
+func short(duration time.Duration) string {
+	switch {
+	case duration >= time.Second:
+		return duration.Round(time.Millisecond).String()
+	case duration >= time.Millisecond:
+		return duration.Round(time.Microsecond).String()
+	default:
+		return duration.String()
+	}
+}
@@ -0,0 +1,287 @@
+package report_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/internal/testkit"
+	"github.com/therenotomorrow/pusher/report"
+)
+
+func TestRecorderEmpty(t *testing.T) {
+	t.Parallel()
+
+	run := report.NewRecorder().Run("empty")
+
+	assert.Equal(t, "empty", run.Title)
+	assert.Equal(t, "total", run.Total.Name)
+	assert.Zero(t, run.Total.Completed)
+	assert.Empty(t, run.Workers)
+	assert.Empty(t, run.Scenarios)
+	assert.Empty(t, run.Errors)
+	assert.Empty(t, run.Timeline)
+	assert.Equal(t, time.Second, run.Interval)
+}
+
+func TestRecorderListen(t *testing.T) {
+	t.Parallel()
+
+	var (
+		recorder = report.NewRecorder(report.WithInterval(time.Hour))
+		gossips  = make(chan *pusher.Gossip, 10)
+		now      = time.Now()
+	)
+
+	canceled := testkit.Gossip(pusher.Canceled, "browse", now, time.Minute, nil)
+	canceled.Tick = now.Add(30 * time.Minute)
+
+	gossips <- canceled
+	gossips <- testkit.Gossip(pusher.BeforeTarget, "browse", now.Add(10*time.Minute), time.Minute, nil)
+	gossips <- testkit.Gossip(pusher.AfterTarget, "browse", now.Add(10*time.Minute), time.Minute, nil)
+	gossips <- testkit.Gossip(pusher.AfterTarget, "search", now.Add(70*time.Minute), time.Minute, testkit.ErrOops)
+	gossips <- testkit.Gossip(pusher.AfterStep, "search", now.Add(70*time.Minute), time.Minute, testkit.ErrOops)
+	gossips <- testkit.Gossip(pusher.AfterTarget, "browse", now.Add(190*time.Minute), time.Minute, nil)
+	// the window is closed already, the gossip goes to the oldest open one
+	gossips <- testkit.Gossip(pusher.AfterTarget, "search", now.Add(10*time.Minute), time.Minute, nil)
+	close(gossips)
+
+	recorder.Listen(t.Context(), pusher.Hire("alice", nil), gossips)
+	recorder.Stop()
+
+	run := recorder.Run("listen")
+
+	assert.Equal(t, int64(4), run.Total.Completed)
+	assert.Equal(t, int64(1), run.Total.Failed)
+	assert.Equal(t, int64(1), run.Total.Canceled)
+	assert.InDelta(t, 0.25, run.Total.ErrorRate, 0.001)
+	assert.Equal(t, time.Minute, run.Total.Latency.Min)
+	assert.Equal(t, time.Minute, run.Total.Latency.Max)
+
+	require.Len(t, run.Workers, 1)
+	assert.Equal(t, "alice", run.Workers[0].Name)
+	assert.Equal(t, run.Total.Completed, run.Workers[0].Completed)
+
+	require.Len(t, run.Scenarios, 2)
+	assert.Equal(t, "browse", run.Scenarios[0].Name)
+	assert.Equal(t, int64(2), run.Scenarios[0].Completed)
+	assert.Equal(t, int64(1), run.Scenarios[0].Canceled)
+	assert.Equal(t, "search", run.Scenarios[1].Name)
+	assert.Equal(t, int64(2), run.Scenarios[1].Completed)
+	assert.Equal(t, int64(1), run.Scenarios[1].Failed)
+
+	assert.Equal(t, []report.Failure{{Kind: "oops", Count: 1}}, run.Errors)
+
+	require.Len(t, run.Timeline, 4)
+
+	for idx, want := range []struct{ completed, failed, canceled int64 }{{1, 0, 1}, {1, 1, 0}, {1, 0, 0}, {1, 0, 0}} {
+		point := run.Timeline[idx]
+
+		assert.Equal(t, time.Duration(idx)*time.Hour, point.Offset)
+		assert.Equal(t, want.completed, point.Completed)
+		assert.Equal(t, want.failed, point.Failed)
+		assert.Equal(t, want.canceled, point.Canceled)
+	}
+}
+
+func TestRecorderWork(t *testing.T) {
+	t.Parallel()
+
+	var (
+		recorder = report.NewRecorder(report.WithInterval(50 * time.Millisecond))
+		target   = func(_ context.Context) (pusher.Result, error) { return testkit.Result("done"), nil }
+		workers  = []*pusher.Worker{
+			pusher.Hire("alice", testkit.Flaky(), pusher.WithGossips(recorder), pusher.WithTasks(20)),
+			pusher.Hire("bob", nil,
+				pusher.WithMix(
+					pusher.Scenario{Target: target, Name: "browse", Weight: 1},
+					pusher.Scenario{Target: target, Name: "search", Weight: 1},
+				),
+				pusher.WithGossips(recorder),
+				pusher.WithTasks(20),
+			),
+		}
+	)
+
+	_, err := pusher.Farm(pusher.Steady(100), 0, workers)
+
+	require.NoError(t, err)
+
+	run := recorder.Run("work")
+
+	assert.Equal(t, int64(40), run.Total.Completed)
+	assert.Equal(t, int64(10), run.Total.Failed)
+	assert.Positive(t, run.Total.RPS)
+	assert.Positive(t, run.Duration)
+
+	require.Len(t, run.Workers, 2)
+	assert.Equal(t, "alice", run.Workers[0].Name)
+	assert.Equal(t, int64(10), run.Workers[0].Failed)
+	assert.Equal(t, "bob", run.Workers[1].Name)
+	assert.Zero(t, run.Workers[1].Failed)
+
+	require.Len(t, run.Scenarios, 2)
+	assert.Equal(t, int64(20), run.Scenarios[0].Completed+run.Scenarios[1].Completed)
+
+	assert.Equal(t, []report.Failure{{Kind: "oops", Count: 10}}, run.Errors)
+
+	var completed int64
+
+	for _, point := range run.Timeline {
+		completed += point.Completed
+	}
+
+	assert.Equal(t, run.Total.Completed, completed)
+	assert.Greater(t, len(run.Timeline), 1)
+}
+
+func TestRecorderWithKind(t *testing.T) {
+	t.Parallel()
+
+	recorder := report.NewRecorder(
+		report.WithKind(func(err error) string { return "kind: " + err.Error() }),
+		report.WithKind(nil),
+		report.WithInterval(0),
+	)
+
+	_, err := pusher.Work(pusher.Steady(1000), 0, testkit.Flaky(), pusher.WithGossips(recorder), pusher.WithTasks(4))
+
+	require.NoError(t, err)
+
+	run := recorder.Run("kind")
+
+	assert.Equal(t, []report.Failure{{Kind: "oops", Count: 2}}, run.Errors)
+	assert.Equal(t, time.Second, run.Interval)
+}
+
+func sample() report.Run {
+	latency := report.Latency{
+		Min:  time.Millisecond,
+		Mean: 12 * time.Millisecond,
+		P50:  10 * time.Millisecond,
+		P90:  20 * time.Millisecond,
+		P99:  1234567 * time.Microsecond,
+		Max:  2 * time.Second,
+	}
+	breakdown := func(name string) report.Breakdown {
+		return report.Breakdown{
+			Name:      name,
+			Latency:   latency,
+			RPS:       99.5,
+			ErrorRate: 0.125,
+			Completed: 8,
+			Failed:    1,
+			Canceled:  2,
+		}
+	}
+
+	return report.Run{
+		Begin:     time.Date(2025, time.March, 8, 12, 0, 0, 0, time.UTC),
+		Title:     "release <v1.2.3>",
+		Workers:   []report.Breakdown{breakdown("alice")},
+		Scenarios: []report.Breakdown{breakdown("browse"), breakdown("search")},
+		Errors:    []report.Failure{{Kind: "unexpected status", Count: 1}},
+		Timeline: []report.Point{
+			{Latency: latency, Offset: 0, RPS: 4, Completed: 4, Failed: 1, Canceled: 0},
+			{Latency: latency, Offset: time.Second, RPS: 4, Completed: 4, Failed: 0, Canceled: 2},
+		},
+		Total:    breakdown("total"),
+		Duration: 2 * time.Second,
+		Interval: time.Second,
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	t.Parallel()
+
+	var text strings.Builder
+
+	require.NoError(t, report.Markdown(&text, sample()))
+
+	got := text.String()
+
+	for _, want := range []string{
+		"# release <v1.2.3>\n",
+		"Began at 2025-03-08T12:00:00Z, lasted 2s.",
+		"## Summary", "## Workers", "## Scenarios", "## Errors",
+		"| name  | completed | failed | canceled | rps   | error rate | min | mean | p50  | p90  | p99    | max |",
+		"| total | 8         | 1      | 2        | 99.50 | 12.50%     | 1ms | 12ms | 10ms | 20ms | 1.235s | 2s  |",
+		"| alice |", "| browse |", "| search |",
+		"| unexpected status | 1     |",
+	} {
+		assert.Contains(t, got, want)
+	}
+}
+
+func TestMarkdownEscape(t *testing.T) {
+	t.Parallel()
+
+	var (
+		text strings.Builder
+		run  = sample()
+	)
+
+	run.Errors = []report.Failure{{Kind: "bad | input\nat line 2", Count: 3}}
+
+	require.NoError(t, report.Markdown(&text, run))
+	assert.Contains(t, text.String(), "| bad \\| input at line 2 | 3     |\n")
+}
+
+func TestMarkdownEmpty(t *testing.T) {
+	t.Parallel()
+
+	var text strings.Builder
+
+	require.NoError(t, report.Markdown(&text, report.NewRecorder().Run("empty")))
+
+	got := text.String()
+
+	assert.Contains(t, got, "## Summary")
+	assert.NotContains(t, got, "## Workers")
+	assert.NotContains(t, got, "## Scenarios")
+	assert.NotContains(t, got, "## Errors")
+}
+
+func TestHTML(t *testing.T) {
+	t.Parallel()
+
+	var page strings.Builder
+
+	require.NoError(t, report.HTML(&page, sample()))
+
+	got := page.String()
+
+	for _, want := range []string{
+		"<title>release &lt;v1.2.3&gt;</title>",
+		"<h2>Workers</h2>", "<h2>Scenarios</h2>", "<h2>Errors</h2>",
+		"<td>unexpected status</td>",
+		"<td>12.50%</td>",
+		`points="0.0,0.0 800.0,0.0"`,
+		`points="0.0,240.0 800.0,120.0"`,
+		"Throughput, per second",
+	} {
+		assert.Contains(t, got, want)
+	}
+
+	assert.Equal(t, 6, strings.Count(got, "<polyline"))
+	assert.NotContains(t, got, "<script")
+}
+
+func TestHTMLEmpty(t *testing.T) {
+	t.Parallel()
+
+	var page strings.Builder
+
+	require.NoError(t, report.HTML(&page, report.NewRecorder().Run("empty")))
+
+	got := page.String()
+
+	assert.Contains(t, got, "<svg")
+	assert.NotContains(t, got, "<h2>Workers</h2>")
+	assert.NotContains(t, got, "NaN")
+}
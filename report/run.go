@@ -0,0 +1,88 @@
+package report
+
+import (
+	"time"
+
+	"github.com/therenotomorrow/pusher/internal/hdr"
+)
+
+const (
+	p50 = 0.5
+	p90 = 0.9
+	p99 = 0.99
+)
+
+type (
+	// Run is the data of the run the report is rendered from, see Recorder.Run.
+	Run struct {
+		// Begin is the moment the Recorder started to listen.
+		Begin time.Time `json:"begin"`
+		// Title names the run in the report, e.g. the release under the test.
+		Title string `json:"title"`
+		// Workers are the breakdowns per Worker sorted by the ident.
+		Workers []Breakdown `json:"workers"`
+		// Scenarios are the breakdowns per Scenario sorted by the name,
+		// it's empty for the workers without the mix.
+		Scenarios []Breakdown `json:"scenarios"`
+		// Errors are the failed tasks by the kind of the error, the most frequent first.
+		Errors []Failure `json:"errors"`
+		// Timeline is the run split into the windows of the Interval.
+		Timeline []Point   `json:"timeline"`
+		Total    Breakdown `json:"total"`
+		// Duration is the wall-clock time the Recorder was listening.
+		Duration time.Duration `json:"duration"`
+		Interval time.Duration `json:"interval"`
+	}
+
+	// Breakdown is the summary of a part of the run.
+	Breakdown struct {
+		Name    string  `json:"name"`
+		Latency Latency `json:"latency"`
+		// RPS is the amount of completed tasks per second.
+		RPS float64 `json:"rps"`
+		// ErrorRate is the share of failed tasks among the completed ones, from 0 to 1.
+		ErrorRate float64 `json:"errorRate"`
+		Completed int64   `json:"completed"`
+		Failed    int64   `json:"failed"`
+		Canceled  int64   `json:"canceled"`
+	}
+
+	// Latency is the distribution of the task latencies.
+	Latency struct {
+		Min  time.Duration `json:"min"`
+		Mean time.Duration `json:"mean"`
+		P50  time.Duration `json:"p50"`
+		P90  time.Duration `json:"p90"`
+		P99  time.Duration `json:"p99"`
+		Max  time.Duration `json:"max"`
+	}
+
+	// Failure is the amount of the failed tasks with the same kind of the error.
+	Failure struct {
+		Kind  string `json:"kind"`
+		Count int64  `json:"count"`
+	}
+
+	// Point is the summary of a single window of the timeline.
+	Point struct {
+		Latency Latency `json:"latency"`
+		// Offset is the start of the window from the beginning of the run.
+		Offset    time.Duration `json:"offset"`
+		RPS       float64       `json:"rps"`
+		Completed int64         `json:"completed"`
+		Failed    int64         `json:"failed"`
+		Canceled  int64         `json:"canceled"`
+	}
+)
+
+// summarize takes the Latency from the histogram.
+func summarize(hist *hdr.Histogram) Latency {
+	return Latency{
+		Min:  hist.Min(),
+		Mean: hist.Mean(),
+		P50:  hist.Quantile(p50),
+		P90:  hist.Quantile(p90),
+		P99:  hist.Quantile(p99),
+		Max:  hist.Max(),
+	}
+}
@@ -0,0 +1,98 @@
+package pusher_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therenotomorrow/ex"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+func TestReportString(t *testing.T) {
+	t.Parallel()
+
+	report := pusher.Report{
+		Ident:     "",
+		Workers:   nil,
+		Duration:  2 * time.Second,
+		RPS:       12.5,
+		Scheduled: 30,
+		Started:   25,
+		Completed: 25,
+		Canceled:  5,
+		Failed:    1,
+	}
+
+	want := "scheduled: 30, started: 25, completed: 25, canceled: 5, failed: 1, rps: 12.50, duration: 2s"
+
+	assert.Equal(t, want, report.String())
+}
+
+func TestWorkReport(t *testing.T) {
+	t.Parallel()
+
+	var num atomic.Int64
+
+	target := func(_ context.Context) (pusher.Result, error) {
+		time.Sleep(20 * time.Millisecond)
+
+		if num.Add(1)%4 == 0 {
+			return nil, ex.ErrUnexpected
+		}
+
+		return result("done"), nil
+	}
+
+	got, err := pusher.Work(pusher.Steady(200), 0, target, pusher.WithOvertime(2), pusher.WithTasks(40))
+
+	require.NoError(t, err)
+	assert.Equal(t, "judas", got.Ident)
+	assert.Empty(t, got.Workers)
+	assert.Equal(t, uint64(40), got.Scheduled)
+	assert.Equal(t, got.Scheduled, got.Started+got.Canceled)
+	assert.Positive(t, got.Canceled)
+	assert.Equal(t, got.Started, got.Completed)
+	assert.Equal(t, got.Completed/4, got.Failed)
+	assert.Positive(t, got.Duration)
+	assert.InEpsilon(t, float64(got.Completed)/got.Duration.Seconds(), got.RPS, 0.001)
+}
+
+func TestCrewReport(t *testing.T) {
+	t.Parallel()
+
+	got, err := pusher.Crew(3, 0, noop(), pusher.WithResults(15))
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(15), got.Scheduled)
+	assert.Equal(t, uint64(15), got.Started)
+	assert.Equal(t, uint64(15), got.Completed)
+	assert.Zero(t, got.Canceled)
+	assert.Zero(t, got.Failed)
+}
+
+func TestFarmReport(t *testing.T) {
+	t.Parallel()
+
+	workers := []*pusher.Worker{
+		pusher.Hire("#1", noop(), pusher.WithTasks(10)),
+		pusher.Hire("#2", noop(), pusher.WithTasks(20)),
+	}
+
+	got, err := pusher.Farm(pusher.Steady(100), 0, workers)
+
+	require.NoError(t, err)
+	assert.Empty(t, got.Ident)
+	require.Len(t, got.Workers, 2)
+	assert.Equal(t, "#1", got.Workers[0].Ident)
+	assert.Equal(t, uint64(10), got.Workers[0].Completed)
+	assert.Equal(t, "#2", got.Workers[1].Ident)
+	assert.Equal(t, uint64(20), got.Workers[1].Completed)
+	assert.Equal(t, uint64(30), got.Scheduled)
+	assert.Equal(t, uint64(30), got.Completed)
+	assert.GreaterOrEqual(t, got.Duration, got.Workers[1].Duration)
+}
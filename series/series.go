@@ -0,0 +1,205 @@
+// Package series provides a Gossiper that splits the run into the windows of
+// a fixed width, so the degradation over time is not hidden by the totals of
+// the whole run, e.g. the warm-up effects or the slow leaks of the soak tests.
+package series
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/therenotomorrow/ex"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/internal/period"
+	"github.com/therenotomorrow/pusher/internal/timeline"
+)
+
+const defaultInterval = time.Second
+
+type (
+	// Bucket is the summary of a single window of the run.
+	Bucket struct {
+		// Start is the moment the window begins.
+		Start time.Time
+		// Offset is the start of the window from the beginning of the run.
+		Offset time.Duration
+		Min    time.Duration
+		Mean   time.Duration
+		P50    time.Duration
+		P90    time.Duration
+		P95    time.Duration
+		P99    time.Duration
+		Max    time.Duration
+		// Throughput is the amount of completed tasks per second of the window.
+		Throughput float64
+		// Completed counts the tasks finished in the window, the failed ones too.
+		Completed int64
+		Failed    int64
+		// Canceled counts the tasks that were due to start in the window.
+		Canceled int64
+	}
+
+	// Collector is a Gossiper that puts every AfterTarget and Canceled gossip into
+	// the window of its moment. It's safe to share one Collector between several
+	// workers, e.g. with pusher.Farm.
+	Collector struct {
+		span     period.Span
+		history  *timeline.Timeline
+		interval time.Duration
+		mutex    sync.Mutex
+	}
+
+	// Option is a functional option for configuring a Collector.
+	Option func(c *Collector)
+)
+
+// WithInterval sets the width of the windows, a second by default.
+func WithInterval(interval time.Duration) Option {
+	return func(c *Collector) {
+		if interval <= 0 {
+			interval = defaultInterval
+		}
+
+		c.interval = interval
+	}
+}
+
+// New creates an empty Collector.
+func New(options ...Option) *Collector {
+	collector := &Collector{
+		span:     period.Span{Begin: time.Time{}, End: time.Time{}},
+		history:  nil,
+		interval: defaultInterval,
+		mutex:    sync.Mutex{},
+	}
+
+	for _, option := range options {
+		option(collector)
+	}
+
+	collector.history = timeline.New(collector.interval)
+
+	return collector
+}
+
+// Listen records the gossips until the channel is closed.
+func (c *Collector) Listen(_ context.Context, _ *pusher.Worker, gossips <-chan *pusher.Gossip) {
+	c.mark(time.Now())
+
+	for gossip := range gossips {
+		if gossip.Canceled() || gossip.AfterTarget() {
+			c.record(gossip)
+		}
+	}
+}
+
+// Stop has nothing to flush, the buckets are complete once the workers are done.
+func (c *Collector) Stop() {}
+
+// Buckets returns the windows recorded so far ordered by the time, the windows
+// without the tasks are there too. The latest windows may still change till
+// the work is over, but after the Stop the slice is complete.
+func (c *Collector) Buckets() []Bucket {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	windows := c.history.Windows()
+	buckets := make([]Bucket, 0, len(windows))
+
+	for _, win := range windows {
+		buckets = append(buckets, Bucket{
+			Start:      c.span.Begin.Add(win.Offset),
+			Offset:     win.Offset,
+			Min:        win.Min,
+			Mean:       win.Mean,
+			P50:        win.P50,
+			P90:        win.P90,
+			P95:        win.P95,
+			P99:        win.P99,
+			Max:        win.Max,
+			Throughput: float64(win.Completed) / c.interval.Seconds(),
+			Completed:  win.Completed,
+			Failed:     win.Failed,
+			Canceled:   win.Canceled,
+		})
+	}
+
+	return buckets
+}
+
+// WriteCSV writes the buckets as CSV with a header, the offset is
+// in seconds and the latencies are in milliseconds.
+func (c *Collector) WriteCSV(writer io.Writer) error {
+	var (
+		table  = csv.NewWriter(writer)
+		header = []string{
+			"start", "offset_s", "completed", "failed", "canceled", "throughput",
+			"min_ms", "mean_ms", "p50_ms", "p90_ms", "p95_ms", "p99_ms", "max_ms",
+		}
+	)
+
+	_ = table.Write(header)
+
+	for _, bucket := range c.Buckets() {
+		_ = table.Write([]string{
+			bucket.Start.Format(time.RFC3339Nano),
+			strconv.FormatFloat(bucket.Offset.Seconds(), 'f', -1, 64),
+			strconv.FormatInt(bucket.Completed, 10),
+			strconv.FormatInt(bucket.Failed, 10),
+			strconv.FormatInt(bucket.Canceled, 10),
+			strconv.FormatFloat(bucket.Throughput, 'f', 2, 64),
+			millis(bucket.Min),
+			millis(bucket.Mean),
+			millis(bucket.P50),
+			millis(bucket.P90),
+			millis(bucket.P95),
+			millis(bucket.P99),
+			millis(bucket.Max),
+		})
+	}
+
+	table.Flush()
+
+	return ex.Conv(table.Error())
+}
+
+// record puts the finished or the canceled task into its window.
+func (c *Collector) record(gossip *pusher.Gossip) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	moment := gossip.End
+	if gossip.Canceled() {
+		moment = gossip.Tick
+	}
+
+	win := c.history.Window(moment.Sub(c.span.Begin))
+
+	switch {
+	case gossip.Canceled():
+		win.Canceled++
+	case gossip.Error != nil:
+		win.Failed++
+
+		fallthrough
+	default:
+		win.Hist.Record(gossip.Latency())
+	}
+}
+
+// mark extends the span of the run with the given moment.
+func (c *Collector) mark(moment time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.span.Mark(moment)
+}
+
+// millis formats the duration in milliseconds.
+func millis(duration time.Duration) string {
+	return strconv.FormatFloat(float64(duration)/float64(time.Millisecond), 'f', 3, 64)
+}
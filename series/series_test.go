@@ -0,0 +1,142 @@
+package series_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/internal/testkit"
+	"github.com/therenotomorrow/pusher/series"
+)
+
+// listen records the gossips of the hour long windows, the run begins right now.
+func listen(t *testing.T) *series.Collector {
+	t.Helper()
+
+	var (
+		collector = series.New(series.WithInterval(time.Hour))
+		gossips   = make(chan *pusher.Gossip, 10)
+		now       = time.Now()
+	)
+
+	gossips <- testkit.Gossip(pusher.Canceled, "", now.Add(30*time.Minute), 0, nil)
+	gossips <- testkit.Gossip(pusher.BeforeTarget, "", now.Add(10*time.Minute), 0, nil)
+	gossips <- testkit.Gossip(pusher.AfterTarget, "", now.Add(10*time.Minute), 10*time.Millisecond, nil)
+	gossips <- testkit.Gossip(pusher.AfterTarget, "", now.Add(20*time.Minute), 30*time.Millisecond, testkit.ErrOops)
+	gossips <- testkit.Gossip(pusher.AfterStep, "", now.Add(20*time.Minute), 20*time.Millisecond, nil)
+	gossips <- testkit.Gossip(pusher.AfterTarget, "", now.Add(190*time.Minute), 5*time.Millisecond, nil)
+	close(gossips)
+
+	collector.Listen(t.Context(), nil, gossips)
+	collector.Stop()
+
+	return collector
+}
+
+func TestCollectorEmpty(t *testing.T) {
+	t.Parallel()
+
+	var text strings.Builder
+
+	collector := series.New(series.WithInterval(0))
+
+	assert.Empty(t, collector.Buckets())
+	require.NoError(t, collector.WriteCSV(&text))
+	assert.Equal(t, "start,offset_s,completed,failed,canceled,throughput,"+
+		"min_ms,mean_ms,p50_ms,p90_ms,p95_ms,p99_ms,max_ms\n", text.String())
+}
+
+func TestCollectorBuckets(t *testing.T) {
+	t.Parallel()
+
+	buckets := listen(t).Buckets()
+
+	require.Len(t, buckets, 4)
+
+	first := buckets[0]
+
+	assert.Equal(t, time.Duration(0), first.Offset)
+	assert.Equal(t, int64(2), first.Completed)
+	assert.Equal(t, int64(1), first.Failed)
+	assert.Equal(t, int64(1), first.Canceled)
+	assert.InDelta(t, 2.0/3600, first.Throughput, 1e-9)
+	assert.Equal(t, 10*time.Millisecond, first.Min)
+	assert.Equal(t, 20*time.Millisecond, first.Mean)
+	assert.Equal(t, 30*time.Millisecond, first.P95)
+	assert.Equal(t, 30*time.Millisecond, first.Max)
+
+	// the windows without the tasks are there too
+	for _, bucket := range buckets[1:3] {
+		assert.Zero(t, bucket.Completed)
+		assert.Zero(t, bucket.Throughput)
+		assert.Zero(t, bucket.P99)
+	}
+
+	last := buckets[3]
+
+	assert.Equal(t, 3*time.Hour, last.Offset)
+	assert.Equal(t, first.Start.Add(3*time.Hour), last.Start)
+	assert.Equal(t, int64(1), last.Completed)
+	assert.Equal(t, 5*time.Millisecond, last.P50)
+}
+
+func TestCollectorWriteCSV(t *testing.T) {
+	t.Parallel()
+
+	var (
+		text      strings.Builder
+		collector = listen(t)
+		start     = collector.Buckets()[0].Start
+	)
+
+	require.NoError(t, collector.WriteCSV(&text))
+
+	lines := strings.Split(strings.TrimSpace(text.String()), "\n")
+
+	require.Len(t, lines, 5)
+	assert.Equal(t, start.Format(time.RFC3339Nano)+",0,2,1,1,0.00,"+
+		"10.000,20.000,10.093,30.000,30.000,30.000,30.000", lines[1])
+	assert.True(t, strings.HasSuffix(lines[2], ",3600,0,0,0,0.00,0.000,0.000,0.000,0.000,0.000,0.000,0.000"))
+	assert.True(t, strings.HasSuffix(lines[4], ",10800,1,0,0,0.00,5.000,5.000,5.000,5.000,5.000,5.000,5.000"))
+
+	require.ErrorIs(t, collector.WriteCSV(testkit.Broken{}), testkit.ErrOops)
+}
+
+func TestCollectorWork(t *testing.T) {
+	t.Parallel()
+
+	var (
+		collector = series.New(series.WithInterval(100 * time.Millisecond))
+		target    = func(_ context.Context) (pusher.Result, error) {
+			time.Sleep(time.Millisecond)
+
+			return testkit.Result("done"), nil
+		}
+	)
+
+	report, err := pusher.Work(pusher.Steady(100), 0, target, pusher.WithGossips(collector), pusher.WithTasks(50))
+
+	require.NoError(t, err)
+
+	var (
+		buckets   = collector.Buckets()
+		completed int64
+	)
+
+	assert.GreaterOrEqual(t, len(buckets), 4)
+
+	for idx, bucket := range buckets {
+		assert.Equal(t, time.Duration(idx)*100*time.Millisecond, bucket.Offset)
+
+		completed += bucket.Completed
+	}
+
+	assert.EqualValues(t, report.Completed, completed)
+	assert.GreaterOrEqual(t, buckets[1].Min, time.Millisecond)
+	assert.InDelta(t, 100, buckets[1].Throughput, 30)
+}
@@ -0,0 +1,198 @@
+// Package stats provides a Gossiper that measures the Target latencies
+// and summarizes them after the run.
+package stats
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/internal/hdr"
+	"github.com/therenotomorrow/pusher/internal/period"
+)
+
+const (
+	p50  = 0.5
+	p90  = 0.9
+	p99  = 0.99
+	p999 = 0.999
+)
+
+type (
+	// Summary is a snapshot of everything the Collector has seen.
+	Summary struct {
+		// Duration is the wall-clock time the Collector was listening.
+		Duration time.Duration
+		Min      time.Duration
+		Max      time.Duration
+		Mean     time.Duration
+		StdDev   time.Duration
+		P50      time.Duration
+		P90      time.Duration
+		P99      time.Duration
+		P999     time.Duration
+		// Throughput is the amount of completed tasks per second.
+		Throughput float64
+		// ErrorRate is the share of failed tasks among the completed ones, from 0 to 1.
+		ErrorRate float64
+		Completed int64
+		Failed    int64
+		Canceled  int64
+	}
+
+	// Collector is a Gossiper that records the time between BeforeTarget and
+	// AfterTarget of every task into a histogram. It's safe to share one
+	// Collector between several workers, e.g. with pusher.Force.
+	Collector struct {
+		span      period.Span
+		hist      *hdr.Histogram
+		measure   func(gossip *pusher.Gossip) time.Duration
+		accept    func(gossip *pusher.Gossip) bool
+		final     pusher.When
+		completed atomic.Int64
+		failed    atomic.Int64
+		canceled  atomic.Int64
+		interval  time.Duration
+		mutex     sync.Mutex
+	}
+
+	// Option is a functional option for configuring a Collector.
+	Option func(c *Collector)
+)
+
+// WithScheduled measures the latency from the intended start of a task
+// (see pusher.Gossip.Response) instead of its actual start. Use it to see
+// the time the tasks were waiting for the overloaded Worker.
+func WithScheduled() Option {
+	return func(c *Collector) {
+		c.measure = (*pusher.Gossip).Response
+	}
+}
+
+// WithScenario records only the tasks of the named pusher.Scenario, so one
+// Collector per Scenario of the mix gives the statistics per endpoint.
+func WithScenario(name string) Option {
+	return func(c *Collector) {
+		accept := c.accept
+		c.accept = func(gossip *pusher.Gossip) bool { return gossip.Scenario == name && accept(gossip) }
+	}
+}
+
+// WithStep records the AfterStep events of the named pusher.Step instead of the
+// whole tasks, so one Collector per Step of the pusher.Flow gives the statistics
+// per step. The canceled tasks have no steps, so they aren't counted.
+func WithStep(name string) Option {
+	return func(c *Collector) {
+		accept := c.accept
+		c.accept = func(gossip *pusher.Gossip) bool { return gossip.Step == name && accept(gossip) }
+		c.final = pusher.AfterStep
+	}
+}
+
+// WithCorrection enables the corrected histogram mode: every latency larger
+// than the expected interval between tasks (usually time.Second / rps) is
+// backfilled with the latencies the omitted tasks would have seen.
+func WithCorrection(interval time.Duration) Option {
+	return func(c *Collector) {
+		c.interval = interval
+	}
+}
+
+// New creates an empty Collector.
+func New(options ...Option) *Collector {
+	collector := &Collector{
+		span:      period.Span{Begin: time.Time{}, End: time.Time{}},
+		hist:      hdr.New(),
+		measure:   (*pusher.Gossip).Latency,
+		accept:    func(*pusher.Gossip) bool { return true },
+		final:     pusher.AfterTarget,
+		completed: atomic.Int64{},
+		failed:    atomic.Int64{},
+		canceled:  atomic.Int64{},
+		interval:  0,
+		mutex:     sync.Mutex{},
+	}
+
+	for _, option := range options {
+		option(collector)
+	}
+
+	return collector
+}
+
+// Listen records the gossips until the channel is closed.
+func (c *Collector) Listen(_ context.Context, _ *pusher.Worker, gossips <-chan *pusher.Gossip) {
+	c.mark(time.Now())
+	defer func() { c.mark(time.Now()) }()
+
+	for gossip := range gossips {
+		if !c.accept(gossip) {
+			continue
+		}
+
+		switch {
+		case gossip.Canceled():
+			c.canceled.Add(1)
+		case gossip.When == c.final:
+			c.completed.Add(1)
+
+			if gossip.Error != nil {
+				c.failed.Add(1)
+			}
+
+			c.hist.RecordCorrected(c.measure(gossip), c.interval)
+		}
+	}
+}
+
+// Stop does nothing, all the gossips are recorded while listening.
+func (c *Collector) Stop() {}
+
+// Summary returns the statistics collected so far.
+func (c *Collector) Summary() Summary {
+	c.mutex.Lock()
+	duration := c.span.Duration()
+	c.mutex.Unlock()
+
+	var (
+		completed  = c.completed.Load()
+		failed     = c.failed.Load()
+		throughput float64
+		errorRate  float64
+	)
+
+	if duration > 0 {
+		throughput = float64(completed) / duration.Seconds()
+	}
+
+	if completed > 0 {
+		errorRate = float64(failed) / float64(completed)
+	}
+
+	return Summary{
+		Duration:   duration,
+		Min:        c.hist.Min(),
+		Max:        c.hist.Max(),
+		Mean:       c.hist.Mean(),
+		StdDev:     c.hist.StdDev(),
+		P50:        c.hist.Quantile(p50),
+		P90:        c.hist.Quantile(p90),
+		P99:        c.hist.Quantile(p99),
+		P999:       c.hist.Quantile(p999),
+		Throughput: throughput,
+		ErrorRate:  errorRate,
+		Completed:  completed,
+		Failed:     failed,
+		Canceled:   c.canceled.Load(),
+	}
+}
+
+// mark extends the listening window with the given moment.
+func (c *Collector) mark(moment time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.span.Mark(moment)
+}
@@ -0,0 +1,219 @@
+package stats_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/therenotomorrow/pusher"
+	"github.com/therenotomorrow/pusher/internal/testkit"
+	"github.com/therenotomorrow/pusher/stats"
+)
+
+// late creates the gossip of the task that started the delay after its tick.
+func late(when pusher.When, delay, latency time.Duration, err error) *pusher.Gossip {
+	gossip := testkit.Gossip(when, "", time.Now(), latency, err)
+	gossip.Tick = gossip.Start.Add(-delay)
+
+	return gossip
+}
+
+func TestCollectorEmpty(t *testing.T) {
+	t.Parallel()
+
+	got := stats.New().Summary()
+
+	assert.Zero(t, got)
+}
+
+func TestCollectorListen(t *testing.T) {
+	t.Parallel()
+
+	var (
+		collector = stats.New()
+		gossips   = make(chan *pusher.Gossip, 10)
+	)
+
+	gossips <- testkit.Gossip(pusher.Canceled, "", time.Now(), 0, nil)
+	gossips <- testkit.Gossip(pusher.BeforeTarget, "", time.Now(), 0, nil)
+	gossips <- testkit.Gossip(pusher.AfterTarget, "", time.Now(), 10*time.Millisecond, nil)
+	gossips <- testkit.Gossip(pusher.BeforeTarget, "", time.Now(), 0, nil)
+	gossips <- testkit.Gossip(pusher.AfterTarget, "", time.Now(), 20*time.Millisecond, testkit.ErrOops)
+	gossips <- testkit.Gossip(pusher.BeforeTarget, "", time.Now(), 0, nil)
+	gossips <- testkit.Gossip(pusher.AfterTarget, "", time.Now(), 30*time.Millisecond, nil)
+	gossips <- testkit.Gossip(pusher.BeforeTarget, "", time.Now(), 0, nil)
+	gossips <- testkit.Gossip(pusher.AfterTarget, "", time.Now(), 40*time.Millisecond, nil)
+	close(gossips)
+
+	collector.Listen(t.Context(), nil, gossips)
+	collector.Stop()
+
+	got := collector.Summary()
+
+	assert.Equal(t, int64(4), got.Completed)
+	assert.Equal(t, int64(1), got.Failed)
+	assert.Equal(t, int64(1), got.Canceled)
+	assert.InDelta(t, 0.25, got.ErrorRate, 0.001)
+	assert.Equal(t, 10*time.Millisecond, got.Min)
+	assert.Equal(t, 40*time.Millisecond, got.Max)
+	assert.Equal(t, 25*time.Millisecond, got.Mean)
+	assert.InEpsilon(t, float64(20*time.Millisecond), float64(got.P50), 0.02)
+	assert.Equal(t, 40*time.Millisecond, got.P99)
+	assert.Equal(t, 40*time.Millisecond, got.P999)
+	assert.Positive(t, got.StdDev)
+	assert.Positive(t, got.Duration)
+	assert.Positive(t, got.Throughput)
+}
+
+func TestCollectorOptions(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		maximum   time.Duration
+		completed int64
+		p50       time.Duration
+	}
+
+	tests := []struct {
+		name    string
+		options []stats.Option
+		want    want
+	}{
+		{
+			name:    "default",
+			options: nil,
+			want:    want{maximum: 50 * time.Millisecond, completed: 2, p50: 10 * time.Millisecond},
+		},
+		{
+			name:    "scheduled",
+			options: []stats.Option{stats.WithScheduled()},
+			want:    want{maximum: 150 * time.Millisecond, completed: 2, p50: 10 * time.Millisecond},
+		},
+		{
+			name:    "corrected",
+			options: []stats.Option{stats.WithCorrection(10 * time.Millisecond)},
+			want:    want{maximum: 50 * time.Millisecond, completed: 2, p50: 20 * time.Millisecond},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				collector = stats.New(test.options...)
+				gossips   = make(chan *pusher.Gossip, 2)
+			)
+
+			gossips <- late(pusher.AfterTarget, 0, 10*time.Millisecond, nil)
+			gossips <- late(pusher.AfterTarget, 100*time.Millisecond, 50*time.Millisecond, nil)
+			close(gossips)
+
+			collector.Listen(t.Context(), nil, gossips)
+			collector.Stop()
+
+			got := collector.Summary()
+
+			assert.Equal(t, test.want.completed, got.Completed)
+			assert.Equal(t, test.want.maximum, got.Max)
+			assert.InEpsilon(t, float64(test.want.p50), float64(got.P50), 0.02)
+		})
+	}
+}
+
+func TestCollectorWork(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rps       = 100
+		delay     = 10 * time.Millisecond
+		collector = stats.New()
+	)
+
+	target := func(_ context.Context) (pusher.Result, error) {
+		time.Sleep(delay)
+
+		return testkit.Result("done"), nil
+	}
+
+	run := pusher.Force(pusher.Steady(rps), time.Second, target, pusher.WithGossips(collector))
+	_, err := run(2)
+
+	require.NoError(t, err)
+
+	got := collector.Summary()
+
+	assert.Greater(t, got.Completed, int64(150))
+	assert.Zero(t, got.Failed)
+	assert.GreaterOrEqual(t, got.Min, delay)
+	assert.GreaterOrEqual(t, got.P50, delay)
+	assert.Greater(t, got.Throughput, 150.0)
+	assert.InDelta(t, time.Second, got.Duration, float64(100*time.Millisecond))
+}
+
+func TestCollectorScenario(t *testing.T) {
+	t.Parallel()
+
+	var (
+		browse = stats.New(stats.WithScenario("browse"))
+		search = stats.New(stats.WithScenario("search"))
+		target = func(_ context.Context) (pusher.Result, error) { return testkit.Result("done"), nil }
+	)
+
+	_, err := pusher.Work(pusher.Steady(1000), 0, nil,
+		pusher.WithMix(
+			pusher.Scenario{Target: target, Name: "browse", Weight: 3},
+			pusher.Scenario{Target: target, Name: "search", Weight: 1},
+		),
+		pusher.WithGossips(browse, search),
+		pusher.WithTasks(400),
+	)
+
+	require.NoError(t, err)
+
+	var (
+		browsed  = browse.Summary().Completed
+		searched = search.Summary().Completed
+	)
+
+	assert.Equal(t, int64(400), browsed+searched)
+	assert.Greater(t, browsed, 2*searched)
+}
+
+func TestCollectorStep(t *testing.T) {
+	t.Parallel()
+
+	var (
+		login  = stats.New(stats.WithStep("login"))
+		logout = stats.New(stats.WithStep("logout"))
+		tasks  = stats.New()
+		step   = func(delay time.Duration, err error) func(context.Context, pusher.State) (pusher.Result, error) {
+			return func(context.Context, pusher.State) (pusher.Result, error) {
+				time.Sleep(delay)
+
+				return testkit.Result("done"), err
+			}
+		}
+	)
+
+	_, err := pusher.Work(pusher.Steady(100), 0,
+		pusher.Flow(
+			pusher.Step{Name: "login", Run: step(10*time.Millisecond, nil)},
+			pusher.Step{Name: "logout", Run: step(0, testkit.ErrOops)},
+		),
+		pusher.WithGossips(login, logout, tasks),
+		pusher.WithTasks(10),
+	)
+
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(10), login.Summary().Completed)
+	assert.Zero(t, login.Summary().Failed)
+	assert.GreaterOrEqual(t, login.Summary().Min, 10*time.Millisecond)
+	assert.Equal(t, int64(10), logout.Summary().Failed)
+	assert.Equal(t, int64(10), tasks.Summary().Completed)
+	assert.Equal(t, int64(10), tasks.Summary().Failed)
+}
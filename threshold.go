@@ -0,0 +1,101 @@
+package pusher
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const percent = 100
+
+// Threshold is a pass criterion of the run (an SLO gate) like "p99 latency
+// is under 300ms" or "error rate is under 1%". The thresholds are checked
+// when the work is over, see WithThresholds. The zero Threshold always passes.
+type Threshold struct {
+	// check returns the description of the breach or an empty string if the run passes.
+	check func(count *tally) string
+}
+
+// Latency creates the Threshold for the quantile of the Target latencies
+// (see Gossip.Latency), e.g. Latency(0.99, 300*time.Millisecond).
+func Latency(quantile float64, limit time.Duration) Threshold {
+	return Threshold{check: func(count *tally) string {
+		value := count.hist.Quantile(quantile)
+		if value <= limit {
+			return ""
+		}
+
+		return fmt.Sprintf("p%g latency %s > %s", quantile*percent, value, limit)
+	}}
+}
+
+// Response creates the Threshold for the quantile of the response times
+// (see Gossip.Response), e.g. Response(0.99, 300*time.Millisecond). Unlike
+// Latency, it counts the time the tasks were late, so the overloaded
+// Worker doesn't hide the slow Target.
+func Response(quantile float64, limit time.Duration) Threshold {
+	return Threshold{check: func(count *tally) string {
+		value := count.response.Quantile(quantile)
+		if value <= limit {
+			return ""
+		}
+
+		return fmt.Sprintf("p%g response %s > %s", quantile*percent, value, limit)
+	}}
+}
+
+// ErrorRate creates the Threshold for the share of failed tasks among
+// the completed ones, from 0 to 1, e.g. ErrorRate(0.01).
+func ErrorRate(limit float64) Threshold {
+	return Threshold{check: func(count *tally) string {
+		var (
+			failed    = count.failed.Load()
+			completed = count.completed.Load()
+			value     float64
+		)
+
+		if completed > 0 {
+			value = float64(failed) / float64(completed)
+		}
+
+		if value <= limit {
+			return ""
+		}
+
+		return fmt.Sprintf("error rate %.2f%% > %.2f%%", value*percent, limit*percent)
+	}}
+}
+
+// Throughput creates the Threshold for the lowest acceptable achieved rate:
+// the amount of completed tasks per second.
+func Throughput(limit float64) Threshold {
+	return Threshold{check: func(count *tally) string {
+		value := rps(count.completed.Load(), count.end.Sub(count.begin))
+		if value >= limit {
+			return ""
+		}
+
+		return fmt.Sprintf("rps %.2f < %.2f", value, limit)
+	}}
+}
+
+// assess checks the thresholds of the Worker against the finished run.
+func (w *Worker) assess(count *tally) error {
+	breaches := make([]string, 0)
+
+	for _, threshold := range w.config.thresholds {
+		if threshold.check == nil {
+			continue
+		}
+
+		if breach := threshold.check(count); breach != "" {
+			breaches = append(breaches, breach)
+		}
+	}
+
+	if len(breaches) == 0 {
+		return nil
+	}
+
+	return ErrThresholdBreached.Reason(strings.Join(breaches, ", "))
+}
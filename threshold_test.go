@@ -0,0 +1,182 @@
+package pusher_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/therenotomorrow/ex"
+
+	"github.com/therenotomorrow/pusher"
+)
+
+func TestWorkerWorkThresholds(t *testing.T) {
+	t.Parallel()
+
+	// every 10th call is slow and every 5th one fails
+	target := func() pusher.Target {
+		var num atomic.Int64
+
+		return func(_ context.Context) (pusher.Result, error) {
+			call := num.Add(1)
+
+			if call%10 == 0 {
+				time.Sleep(50 * time.Millisecond)
+			}
+
+			if call%5 == 0 {
+				return nil, ex.ErrUnexpected
+			}
+
+			return result("done"), nil
+		}
+	}
+
+	type want struct {
+		err    error
+		reason string
+	}
+
+	tests := []struct {
+		want       want
+		name       string
+		thresholds []pusher.Threshold
+	}{
+		{
+			name:       "passed",
+			thresholds: []pusher.Threshold{pusher.Latency(0.5, 10*time.Millisecond), pusher.ErrorRate(0.25)},
+			want:       want{err: nil, reason: ""},
+		},
+		{
+			name:       "latency",
+			thresholds: []pusher.Threshold{pusher.Latency(0.99, 10*time.Millisecond)},
+			want:       want{err: pusher.ErrThresholdBreached, reason: "p99 latency"},
+		},
+		{
+			name:       "response passed",
+			thresholds: []pusher.Threshold{pusher.Response(0.5, 10*time.Millisecond)},
+			want:       want{err: nil, reason: ""},
+		},
+		{
+			name:       "response",
+			thresholds: []pusher.Threshold{pusher.Response(0.99, 10*time.Millisecond)},
+			want:       want{err: pusher.ErrThresholdBreached, reason: "p99 response"},
+		},
+		{
+			name:       "error rate",
+			thresholds: []pusher.Threshold{pusher.ErrorRate(0.01)},
+			want:       want{err: pusher.ErrThresholdBreached, reason: "error rate 20.00% > 1.00%"},
+		},
+		{
+			name:       "throughput",
+			thresholds: []pusher.Threshold{pusher.Throughput(1000)},
+			want:       want{err: pusher.ErrThresholdBreached, reason: "rps"},
+		},
+		{
+			name:       "zero value",
+			thresholds: []pusher.Threshold{{}},
+			want:       want{err: nil, reason: ""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := pusher.Work(
+				pusher.Steady(200),
+				0,
+				target(),
+				pusher.WithTasks(50),
+				pusher.WithThresholds(test.thresholds...),
+			)
+
+			require.ErrorIs(t, err, test.want.err)
+
+			if test.want.reason != "" {
+				assert.Contains(t, err.Error(), test.want.reason)
+			}
+		})
+	}
+}
+
+func TestWorkerWorkThresholdsPlannedEnd(t *testing.T) {
+	t.Parallel()
+
+	// the healthy target is still busy with some tasks at the planned end
+	target := func(ctx context.Context) (pusher.Result, error) {
+		select {
+		case <-time.After(150 * time.Millisecond):
+			return result("done"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	report, err := pusher.Work(pusher.Steady(100), time.Second, target,
+		pusher.WithThresholds(pusher.ErrorRate(0.01)),
+	)
+
+	require.NoError(t, err)
+	assert.Zero(t, report.Failed)
+	assert.Equal(t, report.Started, report.Completed)
+}
+
+func TestWorkerWorkGraceElapsed(t *testing.T) {
+	t.Parallel()
+
+	stuck := func(ctx context.Context) (pusher.Result, error) {
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	}
+
+	report, err := pusher.Work(pusher.Steady(10), 100*time.Millisecond, stuck,
+		pusher.WithGrace(50*time.Millisecond),
+		pusher.WithThresholds(pusher.ErrorRate(0.01)),
+	)
+
+	require.ErrorIs(t, err, pusher.ErrThresholdBreached)
+	assert.Equal(t, report.Started, report.Failed, "the tasks are canceled after the grace period")
+	assert.Less(t, report.Duration, time.Second)
+}
+
+func TestWorkerWorkThresholdsSkipped(t *testing.T) {
+	t.Parallel()
+
+	worker := pusher.Hire("", noop(), pusher.WithThresholds(pusher.Throughput(1000)))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	_, err := worker.Work(ctx, pusher.Steady(10))
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, pusher.ErrThresholdBreached)
+}
+
+func TestFarmThresholds(t *testing.T) {
+	t.Parallel()
+
+	var (
+		gate    = pusher.WithThresholds(pusher.ErrorRate(0))
+		workers = []*pusher.Worker{
+			pusher.Hire("#1", fuzzBuzz(), pusher.WithTasks(10), gate),
+			pusher.Hire("#2", noop(), pusher.WithTasks(10), gate),
+			pusher.Hire("#3", fuzzBuzz(), pusher.WithTasks(10), gate),
+		}
+	)
+
+	got, err := pusher.Farm(pusher.Steady(100), 0, workers)
+
+	require.ErrorIs(t, err, pusher.ErrThresholdBreached)
+	assert.Contains(t, err.Error(), "#1: threshold is breached: error rate")
+	assert.NotContains(t, err.Error(), "#2")
+	assert.Contains(t, err.Error(), "#3: threshold is breached: error rate")
+	assert.Equal(t, uint64(30), got.Scheduled)
+}
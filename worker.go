@@ -2,7 +2,9 @@ package pusher
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -18,6 +20,11 @@ type (
 	// It receives a context for cancellation and must return a Result and an error.
 	Target func(ctx context.Context) (Result, error)
 
+	// Interceptor wraps every call of the Target, e.g. to trace it: it gets the
+	// BeforeTarget Gossip of the task and must pass the call on, possibly with
+	// the derived context. See WithInterceptors.
+	Interceptor func(ctx context.Context, worker *Worker, task *Gossip, call Target) (Result, error)
+
 	// Worker is the core entity that generates a load by repeatedly calling the Target
 	// function at a specified rate (RPS) and concurrency limit.
 	Worker struct {
@@ -28,108 +35,304 @@ type (
 		ident  string
 		config config
 		wait   sync.WaitGroup
-		busy   atomic.Bool
+		// chat tracks the running Gossiper.Listen calls.
+		chat sync.WaitGroup
+		busy atomic.Bool
 	}
 )
 
 // Work starts the load generation loop. It's a blocking method that runs until
-// the provided context is canceled. It generates requests at the specified RPS,
-// respecting the concurrency limit.
-func (w *Worker) Work(ctx context.Context, rps int) error {
-	tick, err := w.validate(rps)
+// the provided context is done, the quota (see WithTasks and WithResults)
+// is reached or the Finite profile is over. It generates requests at the rate given by the Profile,
+// respecting the concurrency limit, and returns the Report of the run.
+// The elapsed deadline of the context is the planned end of the run: the schedule
+// stops and the running tasks get the grace period to finish (see WithGrace).
+// Work returns nil error in that case and an error only when the context is
+// canceled, the run is aborted (see WithAborts), breaches the thresholds
+// (see WithThresholds) or the Worker can't start at all.
+func (w *Worker) Work(ctx context.Context, profile Profile) (Report, error) {
+	err := w.validate(profile)
 	if err != nil {
-		return err
+		return Report{}, err
 	}
 
 	defer w.busy.Store(false)
 
-	tracks := w.runListeners(ctx, rps)
-	defer w.complete(tracks)
+	ctx, abort := context.WithCancelCause(ctx)
+	defer abort(nil)
+
+	tasks, release := w.linger(ctx)
+	defer release()
+
+	var (
+		count  = newTally(newGuard(w.config.aborts, abort))
+		tracks = w.runListeners(ctx, capacity(profile, span(ctx)))
+	)
+
+	err = w.push(ctx, tasks, profile, tracks, count)
+
+	w.complete(tracks, count)
+
+	if err == nil {
+		err = w.assess(count)
+	}
+
+	return count.report(w.ident), err
+}
+
+func (w *Worker) String() string {
+	return w.ident
+}
+
+// push is the load generation loop of Work, it returns when the work is over.
+// The tasks are run within their own context, see linger.
+func (w *Worker) push(ctx, tasks context.Context, profile Profile, tracks []chan *Gossip, count *tally) error {
+	var (
+		rnd      = newRand(w.config.seed, w.stream(0))
+		blend    = w.newMix()
+		pace     = newPacer(profile, w.config.arrival, rnd, count.begin)
+		timeless = time.NewTimer(0)
+	)
 
-	timeless := time.NewTicker(tick)
 	defer timeless.Stop()
 
 	for {
+		if w.exhausted(count) || pace.over() {
+			return nil
+		}
+
+		// Ticks are planned from the beginning of the work, not from the previous
+		// one: a late tick fires immediately instead of being dropped, so the
+		// schedule never drifts and Gossip.Tick always holds the intended moment.
+		moment, fire := pace.next()
+		timeless.Reset(time.Until(moment))
+
 		select {
 		case <-ctx.Done():
-			return ex.Conv(ctx.Err())
+			return finish(ctx)
 
 		case <-timeless.C:
+			if !fire {
+				continue // the profile asks to wait a bit more
+			}
+
+			scenario := blend.pick(rnd)
+			gossip := Gossip{
+				Result:   nil,
+				Error:    nil,
+				Tick:     moment,
+				Start:    time.Time{},
+				End:      time.Time{},
+				When:     Canceled,
+				Scenario: scenario.Name,
+				Step:     "",
+				Seq:      count.scheduled.Add(1),
+			}
+
 			// This inner select attempts to acquire a semaphore slot.
 			// If all slots are busy, it emits a Canceled event and skips the tick.
 			// It also checks for context cancellation for an immediate exit.
 			select {
 			case w.wlb <- struct{}{}:
 			default:
-				w.whisp(tracks, &Gossip{When: Canceled, Result: nil, Error: nil})
+				count.canceled.Add(1)
+				w.whisp(tracks, &gossip)
 
 				continue // move to the next tick
 			}
 
+			count.started.Add(1)
 			w.wait.Go(func() {
 				defer func() { <-w.wlb }()
 
-				w.shout(ctx, tracks, &Gossip{When: BeforeTarget, Result: nil, Error: nil})
-				res, err := w.target(ctx)
-				w.shout(ctx, tracks, &Gossip{When: AfterTarget, Result: res, Error: err})
+				w.execute(tasks, tracks, count, scenario.Target, gossip)
 			})
 		}
 	}
 }
 
-func (w *Worker) String() string {
-	return w.ident
+// execute calls the target once, surrounding it by BeforeTarget and AfterTarget events.
+func (w *Worker) execute(ctx context.Context, tracks []chan *Gossip, count *tally, target Target, gossip Gossip) {
+	before := gossip
+	before.When = BeforeTarget
+	before.Start = time.Now()
+
+	w.shout(ctx, tracks, &before)
+
+	var (
+		call   = ctx
+		teller *herald
+	)
+
+	if len(tracks) > 0 {
+		call, teller = withHerald(ctx, before)
+	}
+
+	after := before
+	after.Result, after.Error = w.intercept(target, &before)(call)
+	after.When = AfterTarget
+	after.End = time.Now()
+
+	count.done(&after)
+
+	for _, step := range teller.told() {
+		w.shout(ctx, tracks, step)
+	}
+
+	w.shout(ctx, tracks, &after)
+}
+
+// intercept wraps the target by the interceptors, the first one is the outermost.
+func (w *Worker) intercept(target Target, task *Gossip) Target {
+	for _, interceptor := range slices.Backward(w.config.interceptors) {
+		next := target
+		target = func(ctx context.Context) (Result, error) {
+			return interceptor(ctx, w, task, next)
+		}
+	}
+
+	return target
+}
+
+// linger returns the context of the tasks of the run. The planned end of the run
+// (the elapsed deadline) stops the schedule only: the running tasks get the grace
+// period (see WithGrace) to finish, so they don't fail just because the time is
+// over. Any other end of the run, e.g. the abort, cancels them at once.
+func (w *Worker) linger(ctx context.Context) (context.Context, context.CancelFunc) {
+	tasks, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+
+	stop := context.AfterFunc(ctx, func() {
+		cause := context.Cause(ctx)
+		if errors.Is(cause, context.DeadlineExceeded) {
+			grace := time.NewTimer(w.config.grace)
+			defer grace.Stop()
+
+			select {
+			case <-grace.C:
+			case <-tasks.Done():
+			}
+		}
+
+		cancel(cause)
+	})
+
+	return tasks, func() {
+		stop()
+		cancel(nil)
+	}
+}
+
+// finish converts the reason the context is done into the result of the work:
+// the elapsed deadline is the planned end of the run, not a failure.
+func finish(ctx context.Context) error {
+	cause := context.Cause(ctx)
+	if errors.Is(cause, context.DeadlineExceeded) {
+		return nil
+	}
+
+	return ex.Conv(cause)
+}
+
+// span returns the planned length of the run given by the deadline of the context,
+// zero if there is no deadline.
+func span(ctx context.Context) time.Duration {
+	end, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+
+	return time.Until(end)
+}
+
+// exhausted checks whether the amount of the scheduled and
+// the started tasks reached the quota of the worker.
+func (w *Worker) exhausted(count *tally) bool {
+	tasks := w.config.tasks > 0 && count.scheduled.Load() >= uint64(w.config.tasks)
+	results := w.config.results > 0 && count.started.Load() >= uint64(w.config.results)
+
+	return tasks || results
 }
 
 // validate performs pre-flight checks before starting the main loop.
-// It ensures the worker is not already busy and validates the RPS value.
-func (w *Worker) validate(rps int) (time.Duration, error) {
-	if w.target == nil {
-		return 0, ErrMissingTarget.Reason("not provided")
+// It ensures the worker is not already busy and validates the Profile.
+func (w *Worker) validate(profile Profile) error {
+	if rate, ok := profile.(*Rate); profile == nil || (ok && rate == nil) {
+		return ErrMissingProfile.Reason("not provided")
+	}
+
+	if fault, ok := profile.(broken); ok {
+		return fault.err
+	}
+
+	// the constant rate is known in advance, so we may check it right now
+	if rate, ok := constant(profile); ok {
+		if rate.Freq < 1 || rate.Per <= 0 {
+			return ErrInvalidRPS.Reason("must be positive")
+		}
+
+		if rate.Interval() < time.Nanosecond {
+			return ErrInvalidRPS.Reason("too large, resulting tick < 1ns")
+		}
 	}
 
-	if rps < 1 {
-		return 0, ErrInvalidRPS.Reason("must be positive")
+	return w.occupy()
+}
+
+// occupy performs the checks common for all the modes and marks the worker busy.
+func (w *Worker) occupy() error {
+	if w.target == nil && len(w.config.scenarios) == 0 {
+		return ErrMissingTarget.Reason("not provided")
 	}
 
-	tick := time.Second / time.Duration(rps)
-	if tick < time.Nanosecond {
-		return 0, ErrInvalidRPS.Reason("too large, resulting tick < 1ns")
+	err := validateMix(w.config.scenarios)
+	if err != nil {
+		return err
 	}
 
 	if w.config.overtime < 0 {
-		return 0, ErrInvalidOvertime.Reason("must be more or equal zero")
+		return ErrInvalidOvertime.Reason("must be more or equal zero")
 	}
 
 	if !w.busy.CompareAndSwap(false, true) {
-		return 0, ErrWorkerIsBusy.Reason("try again later")
+		return ErrWorkerIsBusy.Reason("try again later")
 	}
 
-	return tick, nil
+	return nil
 }
 
 // runListeners starts a goroutine for each configured Gossiper,
-// creating a channel for each to receive events.
-func (w *Worker) runListeners(ctx context.Context, rps int) []chan *Gossip {
+// creating a channel of the given size for each to receive events.
+func (w *Worker) runListeners(ctx context.Context, size int) []chan *Gossip {
 	tracks := make([]chan *Gossip, 0)
 
 	for _, gossiper := range w.config.listeners {
-		tracks = append(tracks, make(chan *Gossip, double*rps))
+		track := make(chan *Gossip, size)
+		tracks = append(tracks, track)
 
-		go gossiper.Listen(ctx, w, tracks[len(tracks)-1])
+		w.chat.Go(func() {
+			gossiper.Listen(ctx, w, track)
+		})
 	}
 
 	return tracks
 }
 
 // complete handles the graceful shutdown of the worker. It waits for all active
-// tasks to finish, then stops and closes all associated listeners and channels.
-func (w *Worker) complete(tracks []chan *Gossip) {
+// tasks to finish, closes all associated channels, waits for the listeners
+// to drain them and only then stops the listeners.
+func (w *Worker) complete(tracks []chan *Gossip, count *tally) {
 	w.wait.Wait()
 
-	for id, track := range tracks {
+	count.end = time.Now()
+
+	for _, track := range tracks {
 		close(track)
-		w.config.listeners[id].Stop()
+	}
+
+	w.chat.Wait()
+
+	for _, listener := range w.config.listeners {
+		listener.Stop()
 	}
 
 	w.busy.Store(false)
@@ -2,6 +2,7 @@ package pusher_test
 
 import (
 	"context"
+	"math"
 	"sync"
 	"testing"
 	"time"
@@ -20,7 +21,7 @@ func TestWorkerValidateTarget(t *testing.T) {
 		worker = pusher.Hire("", target)
 	)
 
-	err := worker.Work(t.Context(), 1)
+	_, err := worker.Work(t.Context(), pusher.Steady(1))
 
 	require.ErrorIs(t, err, pusher.ErrMissingTarget)
 	require.EqualError(t, err, "target is missing: not provided")
@@ -36,7 +37,7 @@ func TestWorkerValidateRPS(t *testing.T) {
 	worker := pusher.Hire("", noop())
 
 	type args struct {
-		rps int
+		rate pusher.Rate
 	}
 
 	type want struct {
@@ -48,16 +49,87 @@ func TestWorkerValidateRPS(t *testing.T) {
 		want want
 		args args
 	}{
-		{name: "zero", args: args{rps: 0}, want: want{err: "invalid rps: must be positive"}},
-		{name: "negative", args: args{rps: -42}, want: want{err: "invalid rps: must be positive"}},
-		{name: "large", args: args{rps: 1e10}, want: want{err: "invalid rps: too large, resulting tick < 1ns"}},
+		{name: "zero", args: args{rate: pusher.Steady(0)}, want: want{err: "invalid rps: must be positive"}},
+		{name: "negative", args: args{rate: pusher.Steady(-42)}, want: want{err: "invalid rps: must be positive"}},
+		{name: "no period", args: args{rate: pusher.Every(0)}, want: want{err: "invalid rps: must be positive"}},
+		{
+			name: "negative period",
+			args: args{rate: pusher.Every(-time.Second)},
+			want: want{err: "invalid rps: must be positive"},
+		},
+		{
+			name: "large",
+			args: args{rate: pusher.Steady(1e10)},
+			want: want{err: "invalid rps: too large, resulting tick < 1ns"},
+		},
 	}
 
 	for _, test := range tests {
-		err := worker.Work(t.Context(), test.args.rps)
+		// the pointer to the Rate is the Profile too
+		for _, profile := range []pusher.Profile{test.args.rate, &test.args.rate} {
+			_, err := worker.Work(t.Context(), profile)
 
-		require.ErrorIs(t, err, pusher.ErrInvalidRPS)
-		require.EqualError(t, err, test.want.err)
+			require.ErrorIs(t, err, pusher.ErrInvalidRPS)
+			require.EqualError(t, err, test.want.err)
+
+			got := worker.Config().Busy
+
+			assert.False(t, got)
+		}
+	}
+}
+
+func TestWorkerValidateProfile(t *testing.T) {
+	t.Parallel()
+
+	worker := pusher.Hire("", noop())
+
+	for _, profile := range []pusher.Profile{nil, (*pusher.Rate)(nil)} {
+		_, err := worker.Work(t.Context(), profile)
+
+		require.ErrorIs(t, err, pusher.ErrMissingProfile)
+		require.EqualError(t, err, "profile is missing: not provided")
+
+		got := worker.Config().Busy
+
+		assert.False(t, got)
+	}
+}
+
+func TestWorkerValidateInvalidProfile(t *testing.T) {
+	t.Parallel()
+
+	worker := pusher.Hire("", noop())
+
+	tests := []struct {
+		profile pusher.Profile
+		name    string
+		want    string
+	}{
+		{name: "steps", profile: pusher.Steps(10, 5, 0), want: "invalid profile: steps must last a positive time"},
+		{
+			name:    "sine",
+			profile: pusher.Sine(100, 50, -time.Second),
+			want:    "invalid profile: sine period must be positive",
+		},
+		{
+			name:    "stage",
+			profile: pusher.Stages(pusher.Stage{Profile: nil, Duration: time.Minute}),
+			want:    "invalid profile: stage has no profile",
+		},
+		{
+			name:    "nested",
+			profile: pusher.Stages(pusher.Stage{Profile: pusher.Steps(10, 5, 0), Duration: time.Minute}),
+			want:    "invalid profile: steps must last a positive time",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := worker.Work(t.Context(), test.profile)
+
+		require.ErrorIs(t, err, pusher.ErrInvalidProfile)
+		require.EqualError(t, err, test.want)
+		assert.Zero(t, test.profile.At(time.Second))
 
 		got := worker.Config().Busy
 
@@ -73,7 +145,7 @@ func TestWorkerValidateOvertime(t *testing.T) {
 		worker = pusher.Hire("", noop(), pusher.WithOvertime(limit))
 	)
 
-	err := worker.Work(t.Context(), 1)
+	_, err := worker.Work(t.Context(), pusher.Steady(1))
 
 	require.ErrorIs(t, err, pusher.ErrInvalidOvertime)
 	require.EqualError(t, err, "invalid overtime: must be more or equal zero")
@@ -100,7 +172,7 @@ func TestWorkerValidateBusy(t *testing.T) {
 
 	for range runs {
 		wait.Go(func() {
-			err := run(ctx, 1)
+			err := run(ctx, pusher.Steady(1))
 			if err == nil {
 				return
 			}
@@ -170,7 +242,7 @@ func TestWorkerWorkFast(t *testing.T) {
 	defer cancel()
 
 	wait.Go(func() {
-		err := run(ctx, rps)
+		err := run(ctx, pusher.Steady(rps))
 
 		require.NoError(t, err)
 	})
@@ -212,7 +284,391 @@ func TestWorkerWorkSlow(t *testing.T) {
 	ctx, cancel := context.WithTimeout(t.Context(), duration)
 	defer cancel()
 
-	err := run(ctx, rps)
+	err := run(ctx, pusher.Steady(rps))
+
+	require.NoError(t, err)
+}
+
+func TestWorkerWorkEnd(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		want want
+		stop func(parent context.Context) (context.Context, context.CancelFunc)
+		name string
+	}{
+		{
+			name: "deadline",
+			stop: func(parent context.Context) (context.Context, context.CancelFunc) {
+				return context.WithTimeout(parent, 100*time.Millisecond)
+			},
+			want: want{err: nil},
+		},
+		{
+			name: "canceled",
+			stop: func(parent context.Context) (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(parent)
+				time.AfterFunc(100*time.Millisecond, cancel)
+
+				return ctx, cancel
+			},
+			want: want{err: context.Canceled},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := test.stop(t.Context())
+			defer cancel()
+
+			_, err := pusher.Hire("", noop()).Work(ctx, pusher.Steady(10))
+
+			require.ErrorIs(t, err, test.want.err)
+		})
+	}
+}
+
+func TestWorkerWorkGossips(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rps      = 50
+		limit    = 1
+		duration = time.Second
+		rec      = newRecorder()
+	)
+
+	_, run := runner(slow(), pusher.WithGossips(rec), pusher.WithOvertime(limit))
+
+	ctx, cancel := context.WithTimeout(t.Context(), duration)
+	defer cancel()
+
+	err := run(ctx, pusher.Steady(rps))
+
+	require.NoError(t, err)
+
+	var (
+		before = make(map[uint64]*pusher.Gossip)
+		after  = make(map[uint64]*pusher.Gossip)
+		seen   = make(map[uint64]bool)
+	)
+
+	gossips := rec.Gossips()
+	require.NotEmpty(t, gossips)
+
+	// the first tick is planned one interval after the beginning
+	begin := gossips[0].Tick.Add(-time.Duration(gossips[0].Seq) * time.Second / time.Duration(rps))
+
+	for _, gossip := range gossips {
+		assert.Positive(t, gossip.Seq)
+		assert.False(t, gossip.Tick.IsZero())
+		// ticks are never dropped, even though the worker is overloaded
+		tick := begin.Add(time.Duration(gossip.Seq) * time.Second / time.Duration(rps))
+		assert.WithinDuration(t, tick, gossip.Tick, time.Microsecond)
+
+		switch {
+		case gossip.Canceled():
+			assert.False(t, seen[gossip.Seq], "canceled task must be unique")
+			assert.True(t, gossip.Start.IsZero())
+		case gossip.BeforeTarget():
+			before[gossip.Seq] = gossip
+		case gossip.AfterTarget():
+			after[gossip.Seq] = gossip
+		}
+
+		seen[gossip.Seq] = true
+	}
+
+	require.NotEmpty(t, after)
+
+	for seq, done := range after {
+		started, ok := before[seq]
+
+		require.True(t, ok, "after target without before target")
+		assert.Equal(t, started.Start, done.Start)
+		assert.Equal(t, started.Tick, done.Tick)
+		assert.False(t, done.Start.Before(done.Tick))
+		assert.False(t, done.End.Before(done.Start))
+	}
+}
+
+func TestWorkerWorkProfile(t *testing.T) {
+	t.Parallel()
+
+	var (
+		duration = 2 * time.Second
+		obs      = newObserver()
+		// 0 -> 100 rps during the first second, 100 rps after: ~150 ticks
+		profile = pusher.Ramp(0, 100, time.Second)
+	)
+
+	_, run := runner(noop(), pusher.WithGossips(obs))
+
+	ctx, cancel := context.WithTimeout(t.Context(), duration)
+	defer cancel()
+
+	err := run(ctx, profile)
+
+	require.NoError(t, err)
+
+	got := int(obs.received.Load())
+
+	assert.InDelta(t, 150, got, 10)
+}
+
+func TestWorkerWorkStages(t *testing.T) {
+	t.Parallel()
+
+	profile := pusher.Stages(
+		pusher.Stage{Profile: pusher.Steady(100), Duration: 200 * time.Millisecond},
+		pusher.Stage{Profile: pusher.Steady(50), Duration: 100 * time.Millisecond},
+	)
+
+	start := time.Now()
+	report, err := pusher.Work(profile, 0, noop())
 
 	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second, "the work is over after the last stage")
+	assert.InDelta(t, 25, report.Completed, 3)
+}
+
+func TestWorkerWorkNotFinite(t *testing.T) {
+	t.Parallel()
+
+	// the non-finite rates pause the load, the load resumes after them
+	profile := pusher.ProfileFunc(func(elapsed time.Duration) float64 {
+		switch {
+		case elapsed < 50*time.Millisecond:
+			return math.NaN()
+		case elapsed < 100*time.Millisecond:
+			return math.Inf(1)
+		default:
+			return 100
+		}
+	})
+
+	report, err := pusher.Work(profile, 300*time.Millisecond, noop())
+
+	require.NoError(t, err)
+	assert.InDelta(t, 20, report.Completed, 3)
+}
+
+func TestWorkerWorkBuffer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		profile pusher.Profile
+		offer   pusher.Offer
+		name    string
+		timeout time.Duration
+		want    int64
+	}{
+		{
+			name:    "spike within the run",
+			profile: pusher.Spike(1, 500, 50*time.Millisecond, 50*time.Millisecond),
+			offer:   pusher.WithTasks(0),
+			timeout: 200 * time.Millisecond,
+			want:    1000,
+		},
+		{
+			name:    "huge rate",
+			profile: pusher.Ramp(0, 1e12, time.Minute),
+			offer:   pusher.WithTasks(1),
+			timeout: 0,
+			want:    1 << 16,
+		},
+		{
+			name: "not a number",
+			profile: pusher.ProfileFunc(func(elapsed time.Duration) float64 {
+				if elapsed > 0 {
+					return math.NaN()
+				}
+
+				return math.Inf(1)
+			}),
+			offer:   pusher.WithTasks(0),
+			timeout: 100 * time.Millisecond,
+			want:    2,
+		},
+		{
+			name:    "ramp without the deadline",
+			profile: pusher.Ramp(0, 10000, time.Minute),
+			offer:   pusher.WithTasks(1),
+			timeout: 0,
+			want:    20000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				meter   = new(gauge)
+				ctx     = t.Context()
+				_, run  = runner(noop(), pusher.WithGossips(meter), test.offer)
+				timeout = context.CancelFunc(func() {})
+			)
+
+			if test.timeout > 0 {
+				ctx, timeout = context.WithTimeout(ctx, test.timeout)
+			}
+
+			defer timeout()
+
+			require.NoError(t, run(ctx, test.profile))
+			assert.Equal(t, test.want, meter.size.Load())
+		})
+	}
+}
+
+func TestWorkerWorkRate(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		rate pusher.Rate
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want int64
+	}{
+		{name: "slow", args: args{rate: pusher.Every(400 * time.Millisecond)}, want: 5},
+		{name: "fractional", args: args{rate: pusher.Rate{Freq: 25, Per: 2 * time.Second}}, want: 25},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			obs := newObserver()
+
+			_, run := runner(noop(), pusher.WithGossips(obs))
+
+			ctx, cancel := context.WithTimeout(t.Context(), 2*time.Second+50*time.Millisecond)
+			defer cancel()
+
+			err := run(ctx, test.args.rate)
+
+			require.NoError(t, err)
+
+			got := obs.received.Load()
+
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestWorkerWorkQuota(t *testing.T) {
+	t.Parallel()
+
+	sleepy := func(_ context.Context) (pusher.Result, error) {
+		time.Sleep(50 * time.Millisecond)
+
+		return result("done"), nil
+	}
+
+	type want struct {
+		scheduled int64
+		completed int64
+	}
+
+	tests := []struct {
+		name   string
+		offers []pusher.Offer
+		want   want
+	}{
+		{
+			name:   "tasks",
+			offers: []pusher.Offer{pusher.WithTasks(50)},
+			want:   want{scheduled: 50, completed: -1},
+		},
+		{
+			name:   "results",
+			offers: []pusher.Offer{pusher.WithResults(20)},
+			want:   want{scheduled: -1, completed: 20},
+		},
+		{
+			name:   "both",
+			offers: []pusher.Offer{pusher.WithTasks(10), pusher.WithResults(20)},
+			want:   want{scheduled: 10, completed: -1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			obs := newObserver()
+			offers := append([]pusher.Offer{pusher.WithGossips(obs), pusher.WithOvertime(2)}, test.offers...)
+
+			_, err := pusher.Work(pusher.Steady(100), 0, sleepy, offers...)
+
+			require.NoError(t, err)
+
+			if test.want.scheduled > 0 {
+				assert.Equal(t, test.want.scheduled, obs.canceled.Load()+obs.received.Load())
+			}
+
+			if test.want.completed > 0 {
+				assert.Equal(t, test.want.completed, obs.success.Load())
+			}
+
+			assert.Equal(t, obs.received.Load(), obs.success.Load())
+		})
+	}
+}
+
+func TestWorkerWorkInterceptors(t *testing.T) {
+	t.Parallel()
+
+	type key struct{}
+
+	var (
+		mutex sync.Mutex
+		trail = make([]string, 0)
+		track = func(name string) pusher.Interceptor {
+			return func(
+				ctx context.Context,
+				worker *pusher.Worker,
+				task *pusher.Gossip,
+				call pusher.Target,
+			) (pusher.Result, error) {
+				mutex.Lock()
+				trail = append(trail, name+" "+worker.String()+" "+string(task.When))
+				mutex.Unlock()
+
+				return call(context.WithValue(ctx, key{}, name))
+			}
+		}
+		target = func(ctx context.Context) (pusher.Result, error) {
+			name, _ := ctx.Value(key{}).(string)
+
+			return result(name), nil
+		}
+		listener = newRecorder()
+	)
+
+	worker := pusher.Hire("tracer", target,
+		pusher.WithInterceptors(track("outer"), track("inner")),
+		pusher.WithGossips(listener),
+		pusher.WithTasks(1),
+	)
+
+	_, err := worker.Work(t.Context(), pusher.Steady(100))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer tracer before-target", "inner tracer before-target"}, trail)
+
+	gossips := listener.Gossips()
+
+	require.Len(t, gossips, 2)
+	assert.Equal(t, "inner", gossips[1].String())
 }